@@ -2,123 +2,396 @@ package main
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
-	"sync"
+
 	"github.com/aquasecurity/trivy/pkg/log"
+	"github.com/aquasecurity/trivy/pkg/result"
 	"github.com/aquasecurity/trivy/pkg/types"
 	"github.com/spf13/cobra"
-	"trivy-plugin-excel/pkg/csv"
+	"trivy-plugin-excel/pkg/exporter"
+	"trivy-plugin-excel/pkg/model"
+	"trivy-plugin-excel/pkg/utils"
+
+	// Register the built-in exporters
+	_ "trivy-plugin-excel/pkg/csv"
 	"trivy-plugin-excel/pkg/excel"
-	"trivy-plugin-excel/pkg/pdf"
+	_ "trivy-plugin-excel/pkg/pdf"
+	"trivy-plugin-excel/pkg/triage"
 )
 
 // main is the entry point for the Trivy report exporter plugin.
 func main() {
-	var output string
-	var beautify bool
+	var flags exportFlags
+	var inputs []string
+	var merge, dedupe bool
 
 	var rootCmd = &cobra.Command{
-		Use:   "report",
-		Short: "Export Trivy results to Excel, PDF, and CSV",
-		Long:  "A Trivy plugin that reads JSON reports from stdin and exports them to specified formats (.xlsx, .pdf, .csv).",
-		Run: func(cmd *cobra.Command, args []string) {
-			var report types.Report
-
-			// Decode the JSON report from standard input (stdin)
-			if err := json.NewDecoder(os.Stdin).Decode(&report); err != nil {
-				log.Fatal("Error reading JSON input: %v", err)
+		Use:           "report [flags] [FILE|DIR|GLOB ...]",
+		Short:         "Export Trivy results to Excel, PDF, and CSV",
+		Long:          "A Trivy plugin that reads JSON reports from files, directories, glob patterns or stdin and exports them to the registered formats (see 'report formats').",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		Args:          cobra.ArbitraryArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Read reports from the given files, directories and globs, or from stdin when none are given
+			reports, err := loadReports(append(inputs, args...))
+			if err != nil {
+				return err
 			}
 
-			// Parse the output filename to determine the extension and base name
-			// Normalize extension to lowercase for consistent comparison
-			ext := strings.ToLower(filepath.Ext(output))
-			baseName := strings.TrimSuffix(output, filepath.Ext(output))
+			baseName, selected, err := selectExporters(flags.output)
+			if err != nil {
+				return err
+			}
+			warnUnsupportedOptions(cmd, selected)
 
-			if baseName == "" {
-				baseName = "report"
+			if dedupe && !merge {
+				log.Warnf("Option --dedupe only applies together with --merge")
 			}
 
-			// Determine which formats to export based on the file extension
-			var exportExcel, exportPdf, exportCsv bool
-
-			switch ext {
-			case ".xlsx":
-				exportExcel = true
-			case ".pdf":
-				exportPdf = true
-			case ".csv":
-				exportCsv = true
-			case "":
-				// If no extension is provided, export to all supported formats by default
-				exportExcel = true
-				exportPdf = true
-				exportCsv = true
-			default:
-				log.Fatal("Unsupported file extension: %s. Supported formats are .xlsx, .pdf, or .csv", ext)
+			return runExports(cmd, buildOutputs(reports, baseName, merge, dedupe), selected, flags)
+		},
+	}
+
+	// Define command-line flags (persistent ones are shared with the subcommands that export)
+	rootCmd.PersistentFlags().StringVarP(&flags.output, "output", "o", "report", "Output filename (e.g., report.xlsx, report.pdf, or just 'report')")
+	rootCmd.PersistentFlags().BoolVarP(&flags.opts.Beautify, "beautify", "b", true, "Enable color formatting (Excel only)")
+	rootCmd.PersistentFlags().BoolVar(&flags.opts.ShowSecretContext, exporter.OptionShowSecretContext, false, "Include unmasked secret matches and code snippets (masked by default)")
+	rootCmd.PersistentFlags().BoolVar(&flags.opts.InventoryAppendix, exporter.OptionInventoryAppendix, false, "Append the package inventory (trivy --list-all-pkgs) to the PDF")
+	rootCmd.PersistentFlags().BoolVar(&flags.opts.DetailsAppendix, exporter.OptionDetailsAppendix, false, "Append the description, CVSS vectors, CWEs, dates, status and references of every vulnerability to the PDF")
+	rootCmd.PersistentFlags().StringSliceVar(&flags.opts.CVSSSources, exporter.OptionCVSSSource, utils.DefaultCVSSSources, "Preferred CVSS vendors in order (e.g. nvd,redhat,ghsa)")
+	rootCmd.PersistentFlags().StringVar(&flags.opts.ExcelLayout, exporter.OptionExcelLayout, "flat", "Vulnerability sheets of the Excel report: flat, per-target or per-class")
+	rootCmd.PersistentFlags().BoolVar(&flags.opts.Triage, exporter.OptionTriage, false, "Add Owner, Decision, Due Date and Comment columns to the Excel vulnerability sheets (see 'report import-triage')")
+	rootCmd.PersistentFlags().StringVar(&flags.opts.Previous, exporter.OptionPrevious, "", "Previous Excel report whose triage columns are carried forward; vulnerabilities gone since are listed as resolved")
+	rootCmd.PersistentFlags().StringVar(&flags.opts.OrgName, exporter.OptionOrgName, "", "Organization named on the PDF cover page")
+	rootCmd.PersistentFlags().StringVar(&flags.opts.OrgLogo, exporter.OptionOrgLogo, "", "PNG or JPEG logo shown on the PDF cover page")
+	rootCmd.PersistentFlags().StringSliceVarP(&flags.filter.Severities, "severity", "s", nil, "Only export findings with these severities (e.g. CRITICAL,HIGH)")
+	rootCmd.PersistentFlags().BoolVar(&flags.filter.IgnoreUnfixed, "ignore-unfixed", false, "Drop vulnerabilities that have no fixed version")
+	rootCmd.PersistentFlags().StringArrayVar(&flags.filter.Expressions, "filter", nil, "Rego query run against each vulnerability and its result, optionally labelled (e.g. 'node:startswith(input.PkgPath, \"/usr/lib/node_modules\")')")
+	rootCmd.PersistentFlags().StringVar((*string)(&flags.filter.Action), "filter-action", string(model.ActionTag), "What to do with vulnerabilities matching --filter: tag or drop")
+	rootCmd.PersistentFlags().StringVar(&flags.suppression.IgnoreFile, "ignorefile", result.DefaultIgnoreFile, "Ignore file (.trivyignore or .trivyignore.yaml) whose entries are suppressed")
+	rootCmd.PersistentFlags().StringSliceVar(&flags.suppression.VEXSources, "vex", nil, "OpenVEX, CycloneDX or CSAF VEX documents whose not-affected statements are suppressed")
+	rootCmd.PersistentFlags().BoolVar(&flags.keepGoing, "keep-going", false, "Export the remaining formats in parallel even if one of them fails")
+
+	rootCmd.Flags().StringSliceVarP(&inputs, "input", "i", nil, "Trivy JSON report files, directories or glob patterns (default: stdin)")
+	rootCmd.Flags().BoolVar(&merge, "merge", false, "Merge all input reports into one consolidated export with an artifact column")
+	rootCmd.Flags().BoolVar(&dedupe, "dedupe", false, "With --merge, list identical vulnerabilities found in several artifacts only once")
+
+	rootCmd.AddCommand(newFormatsCmd())
+	rootCmd.AddCommand(newDiffCmd(&flags))
+	rootCmd.AddCommand(newImportTriageCmd())
+
+	log.InitLogger(false, false)
+
+	// Execute the root command
+	if err := rootCmd.Execute(); err != nil {
+		log.Errorf("%v", err)
+		os.Exit(1)
+	}
+}
+
+// exportFlags holds the flags shared by every command that writes reports.
+type exportFlags struct {
+	output      string
+	opts        exporter.Options
+	keepGoing   bool
+	filter      model.Filter
+	suppression model.Suppression
+}
+
+// selectExporters parses the output filename into a base name and the exporters to run.
+func selectExporters(output string) (string, []exporter.Exporter, error) {
+	// Parse the output filename to determine the extension and base name
+	// Normalize extension to lowercase for consistent comparison
+	ext := strings.ToLower(filepath.Ext(output))
+	baseName := strings.TrimSuffix(output, filepath.Ext(output))
+
+	if baseName == "" {
+		baseName = "report"
+	}
+
+	// Determine which formats to export based on the file extension
+	if ext == "" {
+		// If no extension is provided, export to all registered formats by default
+		return baseName, exporter.All(), nil
+	}
+	if e, ok := exporter.ByExtension(ext); ok {
+		return baseName, []exporter.Exporter{e}, nil
+	}
+	return "", nil, fmt.Errorf("unsupported file extension: %s. Supported formats are %s", ext, strings.Join(exporter.Extensions(), ", "))
+}
+
+// runExports writes every output with the selected exporters and prints the per-format summary.
+// Suppressions, filters and deduplication are applied once per output, before any exporter runs, so every format shows the same findings.
+func runExports(cmd *cobra.Command, outputs []output, selected []exporter.Exporter, flags exportFlags) error {
+	if err := flags.filter.Validate(); err != nil {
+		return err
+	}
+	if err := excel.CheckLayout(flags.opts.ExcelLayout); err != nil {
+		return err
+	}
+
+	var results []exporter.Result
+	failed := false
+	for _, out := range outputs {
+		// Without --keep-going the outputs after a failure are not attempted, but still listed as skipped
+		if failed {
+			results = append(results, exporter.Skip(out.baseName, selected)...)
+			continue
+		}
+		if err := out.report.Suppress(cmd.Context(), flags.suppression); err != nil {
+			return err
+		}
+		if err := out.report.Apply(cmd.Context(), flags.filter); err != nil {
+			return err
+		}
+		// Deduplicate last, so a vulnerability dropped from one artifact is still listed for the others
+		if out.dedupe {
+			out.report.Dedupe()
+		}
+		log.Infof("Generating reports for base name: %s", out.baseName)
+		res := exporter.Run(out.report, out.baseName, selected, flags.opts, flags.keepGoing)
+		results = append(results, res...)
+		failed = !flags.keepGoing && exporter.Failures(res) > 0
+	}
+	exporter.WriteSummary(cmd.ErrOrStderr(), results)
+
+	if n := exporter.Failures(results); n > 0 {
+		return fmt.Errorf("%d of %d report(s) could not be generated", n, len(results))
+	}
+	log.Infof("All reports generated successfully!")
+	return nil
+}
+
+// newDiffCmd compares two scans and exports the introduced, resolved and persisting vulnerabilities.
+func newDiffCmd(flags *exportFlags) *cobra.Command {
+	return &cobra.Command{
+		Use:   "diff OLD.json NEW.json",
+		Short: "Export the vulnerabilities that changed between two scans",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var reports []*types.Report
+			for _, file := range args {
+				report, err := utils.ReadJSONFromFile(file)
+				if err != nil {
+					return fmt.Errorf("error reading %s: %w", file, err)
+				}
+				if report == nil {
+					return fmt.Errorf("%s is not a JSON file", file)
+				}
+				reports = append(reports, report)
 			}
 
-			log.Infof("Generating reports for base name: %s", baseName)
-
-			// Use a WaitGroup to handle concurrent export operations
-			var wg sync.WaitGroup
-
-			// Goroutine 1: Export to Excel
-			if exportExcel {
-				wg.Add(1)
-				go func() {
-					defer wg.Done()
-					fileName := baseName + ".xlsx"
-					if err := excel.Export(&report, fileName, beautify); err != nil {
-						log.Errorf("Failed to export Excel: %v", err)
-					} else {
-						log.Infof("Successfully created: %s", fileName)
-					}
-				}()
+			baseName, selected, err := selectExporters(flags.output)
+			if err != nil {
+				return err
 			}
+			warnUnsupportedOptions(cmd, selected)
+
+			// The new scan is the current state; the diff records what changed relative to the old one
+			m := model.New(reports[1])
+			m.Diff = model.NewDiff(reports[0], reports[1])
 
-			// Goroutine 2: Export to PDF
-			if exportPdf {
-				wg.Add(1)
-				go func() {
-					defer wg.Done()
-					fileName := baseName + ".pdf"
-					if err := pdf.Export(&report, fileName); err != nil {
-						log.Errorf("Failed to export PDF: %v", err)
-					} else {
-						log.Infof("Successfully created: %s", fileName)
-					}
-				}()
+			return runExports(cmd, []output{{baseName: baseName, report: m}}, selected, *flags)
+		},
+	}
+}
+
+// newImportTriageCmd reads the triage decisions of an exported workbook back out as an ignore file or VEX document.
+func newImportTriageCmd() *cobra.Command {
+	var output, format, author string
+	cmd := &cobra.Command{
+		Use:   "import-triage WORKBOOK.xlsx",
+		Short: "Turn the triage decisions of a workbook exported with --triage into a .trivyignore or VEX document",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Checked before the output file is created, so a typo does not truncate an existing one
+			if format != "trivyignore" && format != "vex" {
+				return fmt.Errorf("unsupported triage format: %s (want trivyignore or vex)", format)
+			}
+			entries, err := excel.ReadTriage(args[0])
+			if err != nil {
+				return fmt.Errorf("error reading %s: %w", args[0], err)
 			}
 
-			// Goroutine 3: Export to CSV
-			if exportCsv {
-				wg.Add(1)
-				go func() {
-					defer wg.Done()
-					fileName := baseName + ".csv"
-					// CSV format does not support 'beautify' option
-					if err := csv.Export(&report, fileName); err != nil {
-						log.Errorf("Failed to export CSV: %v", err)
-					} else {
-						log.Infof("Successfully created: %s", fileName)
-					}
-				}()
+			w := cmd.OutOrStdout()
+			var file *os.File
+			if output != "" {
+				if file, err = os.Create(output); err != nil {
+					return err
+				}
+				defer file.Close()
+				w = file
 			}
 
-			// Wait for all export routines to finish
-			wg.Wait()
-			log.Infof("All reports generated successfully!")
+			var imported int
+			if format == "vex" {
+				var skipped []triage.Entry
+				imported, skipped, err = triage.WriteVEX(w, entries, author)
+				for _, e := range skipped {
+					log.Warnf("Skipping %s in %s: no package URL to state it about in VEX", e.VulnerabilityID, e.Package)
+				}
+			} else {
+				ext := strings.ToLower(filepath.Ext(output))
+				imported, err = triage.WriteIgnoreFile(w, entries, ext == ".yaml" || ext == ".yml")
+			}
+			if err != nil {
+				return err
+			}
+			// A failed Close can mean the document never fully reached the disk
+			if file != nil {
+				if err := file.Close(); err != nil {
+					return fmt.Errorf("failed to write %s: %w", output, err)
+				}
+			}
+			log.Infof("Imported %d triage decision(s) from %s", imported, args[0])
+			return nil
 		},
 	}
+	// The local --output shadows the persistent one: there is a single file to write, by default to stdout
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Output file; .yaml or .yml writes .trivyignore.yaml (default: stdout)")
+	cmd.Flags().StringVar(&format, "format", "trivyignore", "Document to write: trivyignore or vex (OpenVEX)")
+	cmd.Flags().StringVar(&author, "author", "", "Author of the VEX document")
+	return cmd
+}
 
-	// Define command-line flags
-	rootCmd.Flags().StringVarP(&output, "output", "o", "report", "Output filename (e.g., report.xlsx, report.pdf, or just 'report')")
-	rootCmd.Flags().BoolVarP(&beautify, "beautify", "b", true, "Enable color formatting (Excel only)")
+// inputReport is a decoded Trivy report together with where it was read from.
+type inputReport struct {
+	source string
+	report *types.Report
+}
 
-	// Execute the root command
-	if err := rootCmd.Execute(); err != nil {
-		os.Exit(1)
+// loadReports reads every Trivy JSON report referenced by inputs.
+// With no inputs (or a single "-") the report is decoded from stdin.
+func loadReports(inputs []string) ([]inputReport, error) {
+	if len(inputs) == 0 || (len(inputs) == 1 && inputs[0] == "-") {
+		var report types.Report
+		if err := json.NewDecoder(os.Stdin).Decode(&report); err != nil {
+			return nil, fmt.Errorf("error reading JSON input: %w", err)
+		}
+		return []inputReport{{source: "stdin", report: &report}}, nil
+	}
+
+	files, err := utils.ExpandInputs(inputs)
+	if err != nil {
+		return nil, err
+	}
+
+	var reports []inputReport
+	for _, file := range files {
+		report, err := utils.ReadJSONFromFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("error reading %s: %w", file, err)
+		}
+		if report == nil {
+			log.Warnf("Skipping %s: not a JSON file", file)
+			continue
+		}
+		reports = append(reports, inputReport{source: file, report: report})
+	}
+
+	if len(reports) == 0 {
+		return nil, fmt.Errorf("no Trivy JSON reports found in %s", strings.Join(inputs, ", "))
+	}
+	return reports, nil
+}
+
+// output is a report model together with the base name its files are written to.
+type output struct {
+	baseName string
+	report   *model.Report
+	// dedupe lists identical vulnerabilities of several artifacts only once (--merge --dedupe)
+	dedupe bool
+}
+
+// buildOutputs turns the decoded inputs into report models.
+// Merging yields a single model; otherwise several inputs get their own outputs, suffixed with a name unique to the input.
+func buildOutputs(reports []inputReport, baseName string, merge, dedupe bool) []output {
+	if merge || len(reports) == 1 {
+		var trivyReports []*types.Report
+		for _, in := range reports {
+			trivyReports = append(trivyReports, in.report)
+		}
+		return []output{{baseName: baseName, report: model.New(trivyReports...), dedupe: merge && dedupe}}
+	}
+
+	var outputs []output
+	for i, name := range outputNames(reports) {
+		outputs = append(outputs, output{baseName: baseName + "-" + name, report: model.New(reports[i].report)})
+	}
+	return outputs
+}
+
+// outputNames returns a distinct output suffix per input: its file name without extension, prefixed with
+// the parent directory when several inputs share a file name (scans/a/trivy.json -> a-trivy), and
+// numbered by input position when that still collides, so no export overwrites another.
+func outputNames(reports []inputReport) []string {
+	stem := func(source string) string {
+		return strings.TrimSuffix(filepath.Base(source), filepath.Ext(source))
+	}
+	counts := make(map[string]int)
+	for _, in := range reports {
+		counts[stem(in.source)]++
+	}
+
+	names := make([]string, len(reports))
+	taken := make(map[string]int)
+	for i, in := range reports {
+		names[i] = stem(in.source)
+		if counts[names[i]] > 1 {
+			names[i] = filepath.Base(filepath.Dir(in.source)) + "-" + names[i]
+		}
+		taken[names[i]]++
+	}
+	for i, name := range names {
+		if taken[name] <= 1 {
+			continue
+		}
+		n := i + 1
+		for taken[fmt.Sprintf("%s-%d", name, n)] > 0 {
+			n++
+		}
+		names[i] = fmt.Sprintf("%s-%d", name, n)
+		taken[names[i]]++
+	}
+	return names
+}
+
+// newFormatsCmd lists the registered export formats and the options each one supports.
+func newFormatsCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "formats",
+		Short: "List the supported export formats",
+		Run: func(cmd *cobra.Command, args []string) {
+			for _, e := range exporter.All() {
+				options := strings.Join(e.SupportedOptions(), ", ")
+				if options == "" {
+					options = "-"
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "%-8s %-6s options: %s\n", e.Name(), e.Extension(), options)
+			}
+		},
+	}
+}
+
+// warnUnsupportedOptions logs a warning for every explicitly set flag that none of the selected exporters honours.
+func warnUnsupportedOptions(cmd *cobra.Command, selected []exporter.Exporter) {
+	for _, option := range []string{exporter.OptionBeautify, exporter.OptionShowSecretContext, exporter.OptionInventoryAppendix, exporter.OptionDetailsAppendix, exporter.OptionCVSSSource, exporter.OptionExcelLayout, exporter.OptionTriage, exporter.OptionPrevious, exporter.OptionOrgName, exporter.OptionOrgLogo} {
+		if !cmd.Flags().Changed(option) {
+			continue
+		}
+		supported := false
+		for _, e := range selected {
+			if exporter.Supports(e, option) {
+				supported = true
+				break
+			}
+		}
+		if !supported {
+			log.Warnf("Option --%s is ignored by the selected format(s)", option)
+		}
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aquasecurity/trivy/pkg/types"
+	"github.com/spf13/cobra"
+	"trivy-plugin-excel/pkg/exporter"
+	"trivy-plugin-excel/pkg/model"
+)
+
+// captureExporter records the report it is asked to export instead of writing a file.
+type captureExporter struct {
+	report *model.Report
+}
+
+func (e *captureExporter) Name() string               { return "capture" }
+func (e *captureExporter) Extension() string          { return ".capture" }
+func (e *captureExporter) SupportedOptions() []string { return nil }
+
+func (e *captureExporter) Export(report *model.Report, path string, opts exporter.Options) error {
+	e.report = report
+	return nil
+}
+
+func TestMergeDedupeKeepsCopiesNotSuppressed(t *testing.T) {
+	vuln := types.DetectedVulnerability{VulnerabilityID: "CVE-2024-0001", PkgName: "libssl3", InstalledVersion: "3.1.0"}
+	reports := []inputReport{
+		{source: "a.json", report: &types.Report{ArtifactName: "a", Results: types.Results{{Target: "a/app", Vulnerabilities: []types.DetectedVulnerability{vuln}}}}},
+		{source: "b.json", report: &types.Report{ArtifactName: "b", Results: types.Results{{Target: "b/app", Vulnerabilities: []types.DetectedVulnerability{vuln}}}}},
+	}
+
+	// The ignore entry only covers the copy of the first artifact, the one deduplication would keep
+	ignoreFile := filepath.Join(t.TempDir(), ".trivyignore.yaml")
+	if err := os.WriteFile(ignoreFile, []byte("vulnerabilities:\n  - id: CVE-2024-0001\n    paths:\n      - a/app\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	cmd.SetErr(io.Discard)
+	capture := &captureExporter{}
+	flags := exportFlags{suppression: model.Suppression{IgnoreFile: ignoreFile}}
+	if err := runExports(cmd, buildOutputs(reports, filepath.Join(t.TempDir(), "report"), true, true), []exporter.Exporter{capture}, flags); err != nil {
+		t.Fatal(err)
+	}
+
+	found := map[string]int{}
+	for _, a := range capture.report.Artifacts {
+		for _, result := range a.Results {
+			found[a.Name] += len(result.Vulnerabilities)
+		}
+	}
+	if found["a"] != 0 || found["b"] != 1 {
+		t.Errorf("vulnerabilities per artifact = %v, want the copy of b kept and the one of a suppressed", found)
+	}
+	if len(capture.report.Suppressed) != 1 || capture.report.Suppressed[0].Artifact != "a" {
+		t.Errorf("suppressed = %+v, want the copy of a", capture.report.Suppressed)
+	}
+}
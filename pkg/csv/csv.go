@@ -4,10 +4,46 @@ import (
 	"encoding/csv"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
+
 	"github.com/aquasecurity/trivy/pkg/types"
+	"trivy-plugin-excel/pkg/exporter"
+	"trivy-plugin-excel/pkg/model"
+	"trivy-plugin-excel/pkg/utils"
 )
 
+func init() {
+	exporter.Register(Exporter{})
+}
+
+// Exporter adapts Export to the exporter.Exporter interface.
+type Exporter struct{}
+
+func (Exporter) Name() string      { return "csv" }
+func (Exporter) Extension() string { return ".csv" }
+
+func (Exporter) SupportedOptions() []string {
+	return []string{exporter.OptionShowSecretContext, exporter.OptionCVSSSource}
+}
+
+func (Exporter) Export(report *model.Report, path string, opts exporter.Options) error {
+	return Export(report, path, opts)
+}
+
+// Siblings returns the paths of the files written next to the vulnerability CSV at path.
+func (Exporter) Siblings(path string) []string {
+	paths := make([]string, len(siblingKinds))
+	for i, kind := range siblingKinds {
+		paths[i] = siblingPath(path, kind)
+	}
+	return paths
+}
+
+// siblingKinds are the finding kinds written to a CSV file of their own, in the order they are written.
+var siblingKinds = []string{"misconfigurations", "secrets", "suppressed", "filters", "licenses"}
+
 // sanitize prevents CSV Injection (Formula Injection).
 func sanitize(s string) string {
 	if len(s) > 0 && (strings.HasPrefix(s, "=") || strings.HasPrefix(s, "+") || strings.HasPrefix(s, "-") || strings.HasPrefix(s, "@")) {
@@ -17,7 +53,71 @@ func sanitize(s string) string {
 }
 
 // Export writes the Trivy scan report to a CSV file at the specified path.
-func Export(report *types.Report, path string) error {
+// Other finding kinds are written next to it (e.g. report-misconfigurations.csv), with just a header
+// when there are none, so no stale file of an earlier export is left behind.
+func Export(report *model.Report, path string, opts exporter.Options) error {
+	// 1. Build the CSV Header (merged reports lead with the artifact, diff reports with the change category)
+	header := []string{
+		"Target", "Type", "Vulnerability ID", "Severity",
+		"CVSS Source", "CVSS v3 Score", "CVSS v3 Vector", "Pkg Name", "Installed Version", "Fixed Version",
+		"Title", "Primary URL", "Tag",
+	}
+	merged := report.Merged()
+	if merged {
+		header = append([]string{"Artifact"}, header...)
+	}
+	if report.Diff != nil {
+		header = append([]string{"Change"}, header...)
+	}
+
+	// 2. Build data rows: diff findings by change category, otherwise results grouped by artifact
+	var rows [][]string
+	if report.Diff != nil {
+		for _, change := range model.Changes {
+			for _, finding := range report.Diff.ByChange(change) {
+				row := vulnRecord(finding.Artifact, finding.Target, finding.Class, finding.Vulnerability, opts.CVSSSources, merged)
+				row = append(row, sanitize(report.Tag(finding.Artifact, finding.Target, finding.Vulnerability)))
+				rows = append(rows, append([]string{string(change)}, row...))
+			}
+		}
+	} else {
+		for ai := range report.Artifacts {
+			artifact := &report.Artifacts[ai]
+			for _, result := range artifact.Results {
+				for _, vuln := range result.Vulnerabilities {
+					row := vulnRecord(report.ArtifactLabel(artifact, vuln), result.Target, result.Class, vuln, opts.CVSSSources, merged)
+					rows = append(rows, append(row, sanitize(report.Tag(artifact.Name, result.Target, vuln))))
+				}
+			}
+		}
+	}
+
+	if err := writeCSV(path, header, rows); err != nil {
+		return err
+	}
+
+	// 3. Write the other finding kinds to sibling files
+	if err := writeCSV(siblingPath(path, "misconfigurations"), misconfHeader, misconfRecords(report)); err != nil {
+		return err
+	}
+	if err := writeCSV(siblingPath(path, "secrets"), secretHeader, secretRecords(report, opts.ShowSecretContext)); err != nil {
+		return err
+	}
+	if err := writeCSV(siblingPath(path, "suppressed"), suppressedHeader, suppressedRecords(report)); err != nil {
+		return err
+	}
+	var filters [][]string
+	for _, filter := range report.Filter.Describe() {
+		filters = append(filters, []string{sanitize(filter)})
+	}
+	if err := writeCSV(siblingPath(path, "filters"), []string{"Active Filter"}, filters); err != nil {
+		return err
+	}
+	return writeCSV(siblingPath(path, "licenses"), licenseHeader, licenseRecords(report))
+}
+
+// writeCSV writes the header and rows to a new CSV file.
+func writeCSV(path string, header []string, rows [][]string) error {
 	// 1. Create the output file
 	file, err := os.Create(path)
 	if err != nil {
@@ -27,56 +127,79 @@ func Export(report *types.Report, path string) error {
 
 	// 2. Initialize the CSV writer
 	writer := csv.NewWriter(file)
-	defer writer.Flush()
 
 	// 3. Write the CSV Header
-	header := []string{
-		"Target", "Type", "Vulnerability ID", "Severity",
-		"Pkg Name", "Installed Version", "Fixed Version",
-		"Title", "Primary URL",
-	}
 	if err := writer.Write(header); err != nil {
 		return fmt.Errorf("failed to write header: %w", err)
 	}
 
-	// 4. Iterate through results and write data rows
-	for _, result := range report.Results {
-		// Skip results with no vulnerabilities
-		if len(result.Vulnerabilities) == 0 {
-			continue
+	// 4. Write data rows
+	for i, row := range rows {
+		if err := writer.Write(row); err != nil {
+			return fmt.Errorf("error writing record %d: %w", i+1, err)
 		}
+	}
 
-		for _, vuln := range result.Vulnerabilities {
-			// Handle missing fixed version
-			fixedVer := vuln.FixedVersion
-			if fixedVer == "" {
-				fixedVer = "-"
-			}
+	// 5. Flush buffered rows so write errors are reported instead of lost
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("failed to flush CSV file: %w", err)
+	}
+	return file.Close()
+}
 
-			// Get the primary URL (if available)
-			primaryURL := ""
-			if len(vuln.References) > 0 {
-				primaryURL = vuln.References[0]
-			}
+// siblingPath derives the path of a companion CSV file, e.g. report.csv -> report-licenses.csv.
+func siblingPath(path, kind string) string {
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + "-" + kind + ext
+}
 
-			// Apply sanitization to all fields to prevent injection attacks
-			row := []string{
-				sanitize(result.Target),
-				sanitize(string(result.Class)),
-				sanitize(vuln.VulnerabilityID),
-				sanitize(vuln.Severity),
-				sanitize(vuln.PkgName),
-				sanitize(vuln.InstalledVersion),
-				sanitize(fixedVer),
-				sanitize(vuln.Title),
-				sanitize(primaryURL),
-			}
+// vulnRecord builds a sanitized CSV row for a single vulnerability, leading with the artifact name when withArtifact is set.
+func vulnRecord(artifactName, target string, class types.ResultClass, vuln types.DetectedVulnerability, cvssSources []string, withArtifact bool) []string {
+	// Handle missing fixed version
+	fixedVer := vuln.FixedVersion
+	if fixedVer == "" {
+		fixedVer = "-"
+	}
 
-			if err := writer.Write(row); err != nil {
-				return fmt.Errorf("error writing record for %s: %w", vuln.VulnerabilityID, err)
-			}
-		}
+	// Get the primary URL (if available)
+	primaryURL := ""
+	if len(vuln.References) > 0 {
+		primaryURL = vuln.References[0]
+	}
+
+	// Pick the CVSS v3 score from the preferred vendor (empty when none is available)
+	cvssScore := ""
+	cvssSource, score, cvssVector, ok := utils.PreferredCVSS(vuln, cvssSources)
+	if ok {
+		cvssScore = strconv.FormatFloat(score, 'f', 1, 64)
 	}
 
-	return nil
-}
\ No newline at end of file
+	// Apply sanitization to all fields to prevent injection attacks
+	record := []string{
+		sanitize(target),
+		sanitize(string(class)),
+		sanitize(vuln.VulnerabilityID),
+		sanitize(vuln.Severity),
+		sanitize(cvssSource),
+		cvssScore,
+		sanitize(cvssVector),
+		sanitize(vuln.PkgName),
+		sanitize(vuln.InstalledVersion),
+		sanitize(fixedVer),
+		sanitize(vuln.Title),
+		sanitize(primaryURL),
+	}
+	if withArtifact {
+		record = append([]string{sanitize(artifactName)}, record...)
+	}
+	return record
+}
+
+// lineNumber renders a line number, leaving the cell empty when unknown.
+func lineNumber(n int) string {
+	if n <= 0 {
+		return ""
+	}
+	return strconv.Itoa(n)
+}
@@ -0,0 +1,35 @@
+package csv
+
+import (
+	"strconv"
+
+	"trivy-plugin-excel/pkg/model"
+)
+
+var licenseHeader = []string{
+	"Artifact", "Target", "Severity", "Category", "Package Name",
+	"File Path", "License", "Confidence", "Link",
+}
+
+// licenseRecords builds sanitized CSV rows for every license finding, grouped by artifact.
+func licenseRecords(report *model.Report) [][]string {
+	var rows [][]string
+	for _, artifact := range report.Artifacts {
+		for _, result := range artifact.Results {
+			for _, license := range result.Licenses {
+				rows = append(rows, []string{
+					sanitize(artifact.Name),
+					sanitize(result.Target),
+					sanitize(license.Severity),
+					sanitize(string(license.Category)),
+					sanitize(license.PkgName),
+					sanitize(license.FilePath),
+					sanitize(license.Name),
+					strconv.FormatFloat(license.Confidence, 'f', -1, 64),
+					sanitize(license.Link),
+				})
+			}
+		}
+	}
+	return rows
+}
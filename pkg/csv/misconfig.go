@@ -0,0 +1,43 @@
+package csv
+
+import (
+	"trivy-plugin-excel/pkg/model"
+	"trivy-plugin-excel/pkg/utils"
+)
+
+var misconfHeader = []string{
+	"Artifact", "Target", "Class", "Check Type", "ID", "AVD ID", "Title",
+	"Severity", "Status", "Resolution", "Resource", "Provider", "Service",
+	"Start Line", "End Line", "Primary URL",
+}
+
+// misconfRecords builds sanitized CSV rows for every misconfiguration, grouped by artifact.
+func misconfRecords(report *model.Report) [][]string {
+	var rows [][]string
+	for _, artifact := range report.Artifacts {
+		for _, result := range artifact.Results {
+			for _, misconf := range result.Misconfigurations {
+				cause := misconf.CauseMetadata
+				rows = append(rows, []string{
+					sanitize(artifact.Name),
+					sanitize(result.Target),
+					sanitize(utils.SetResultClass(result.Class)),
+					sanitize(misconf.Type),
+					sanitize(misconf.ID),
+					sanitize(misconf.AVDID),
+					sanitize(misconf.Title),
+					sanitize(misconf.Severity),
+					sanitize(string(misconf.Status)),
+					sanitize(misconf.Resolution),
+					sanitize(cause.Resource),
+					sanitize(cause.Provider),
+					sanitize(cause.Service),
+					lineNumber(cause.StartLine),
+					lineNumber(cause.EndLine),
+					sanitize(misconf.PrimaryURL),
+				})
+			}
+		}
+	}
+	return rows
+}
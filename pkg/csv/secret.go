@@ -0,0 +1,37 @@
+package csv
+
+import (
+	"trivy-plugin-excel/pkg/model"
+	"trivy-plugin-excel/pkg/utils"
+)
+
+var secretHeader = []string{
+	"Artifact", "Target", "Rule ID", "Category", "Severity", "Title",
+	"Start Line", "End Line", "Match", "Code",
+}
+
+// secretRecords builds sanitized CSV rows for every secret finding, grouped by artifact.
+// Matches and code snippets are masked unless showContext is set.
+func secretRecords(report *model.Report, showContext bool) [][]string {
+	var rows [][]string
+	for _, artifact := range report.Artifacts {
+		for _, result := range artifact.Results {
+			for _, secret := range result.Secrets {
+				match, code := utils.SecretContext(secret, showContext)
+				rows = append(rows, []string{
+					sanitize(artifact.Name),
+					sanitize(result.Target),
+					sanitize(secret.RuleID),
+					sanitize(string(secret.Category)),
+					sanitize(secret.Severity),
+					sanitize(secret.Title),
+					lineNumber(secret.StartLine),
+					lineNumber(secret.EndLine),
+					sanitize(match),
+					sanitize(code),
+				})
+			}
+		}
+	}
+	return rows
+}
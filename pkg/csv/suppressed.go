@@ -0,0 +1,30 @@
+package csv
+
+import (
+	"trivy-plugin-excel/pkg/model"
+)
+
+var suppressedHeader = []string{
+	"Artifact", "Target", "Finding Type", "ID", "Package", "Severity",
+	"Status", "Justification", "Source", "Expires",
+}
+
+// suppressedRecords builds sanitized CSV rows for the findings accepted by an ignore file or VEX statement.
+func suppressedRecords(report *model.Report) [][]string {
+	var rows [][]string
+	for _, s := range report.Suppressed {
+		rows = append(rows, []string{
+			sanitize(s.Artifact),
+			sanitize(s.Target),
+			sanitize(string(s.Type)),
+			sanitize(s.ID),
+			sanitize(s.Package),
+			sanitize(s.Severity),
+			sanitize(string(s.Status)),
+			sanitize(s.Statement),
+			sanitize(s.Source),
+			s.Expires(),
+		})
+	}
+	return rows
+}
@@ -0,0 +1,132 @@
+package excel
+
+import (
+	"fmt"
+
+	"github.com/xuri/excelize/v2"
+	"trivy-plugin-excel/pkg/model"
+)
+
+// ChartColumn is where the Summary charts are placed, to the right of its tables.
+const ChartColumn = "I"
+
+// addSummaryCharts draws native charts from the Summary tables: the severity distribution,
+// severities stacked per target and the most vulnerable packages. Charts without data are skipped.
+func addSummaryCharts(f *excelize.File, layout summaryLayout) error {
+	if layout.total == 0 {
+		return nil
+	}
+	applySeverityTheme(f)
+
+	sheet := SummaryReport
+	ref := func(col string, first, last int) string {
+		return fmt.Sprintf("%s!$%s$%d:$%s$%d", sheet, col, first, col, last)
+	}
+	dimension := excelize.ChartDimension{Width: 640, Height: 320}
+	anchor := 2
+
+	// 1. Severity distribution, colored through the theme accents (see applySeverityTheme)
+	varyColors, fixedColors := true, false
+	if err := f.AddChart(sheet, fmt.Sprintf("%s%d", ChartColumn, anchor), &excelize.Chart{
+		Type:       excelize.Doughnut,
+		Title:      []excelize.RichTextRun{{Text: "Vulnerabilities by Severity"}},
+		VaryColors: &varyColors,
+		Series: []excelize.ChartSeries{{
+			Name:       fmt.Sprintf("%s!$B$%d", sheet, layout.severities[0]-1),
+			Categories: ref("A", layout.severities[0], layout.severities[1]),
+			Values:     ref("B", layout.severities[0], layout.severities[1]),
+		}},
+		Legend:    excelize.ChartLegend{Position: "right"},
+		PlotArea:  excelize.ChartPlotArea{ShowVal: true},
+		HoleSize:  50,
+		Dimension: dimension,
+	}); err != nil {
+		return fmt.Errorf("failed to add severity chart: %w", err)
+	}
+	anchor += 17
+
+	// 2. One stacked bar per target, a series per severity
+	if layout.targets[1] >= layout.targets[0] {
+		var series []excelize.ChartSeries
+		for i, severity := range model.Severities {
+			col, _ := excelize.ColumnNumberToName(i + 2)
+			series = append(series, excelize.ChartSeries{
+				Name:       fmt.Sprintf("%s!$%s$%d", sheet, col, layout.targets[0]-1),
+				Categories: ref("A", layout.targets[0], layout.targets[1]),
+				Values:     ref(col, layout.targets[0], layout.targets[1]),
+				Fill:       excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{SeverityColor[severity]}},
+			})
+		}
+		height := dimension.Height
+		if rows := uint(layout.targets[1]-layout.targets[0]+1) * 30; rows+120 > height {
+			height = rows + 120
+		}
+		if err := f.AddChart(sheet, fmt.Sprintf("%s%d", ChartColumn, anchor), &excelize.Chart{
+			Type:       excelize.BarStacked,
+			Title:      []excelize.RichTextRun{{Text: "Vulnerabilities by Target"}},
+			VaryColors: &fixedColors,
+			Series:     series,
+			Legend:     excelize.ChartLegend{Position: "bottom"},
+			YAxis:      excelize.ChartAxis{MajorGridLines: true},
+			XAxis:      excelize.ChartAxis{ReverseOrder: true},
+			Dimension:  excelize.ChartDimension{Width: dimension.Width, Height: height},
+		}); err != nil {
+			return fmt.Errorf("failed to add target chart: %w", err)
+		}
+		anchor += int(height/20) + 2
+	}
+
+	// 3. Top packages by vulnerability count
+	if layout.packages[1] >= layout.packages[0] {
+		if err := f.AddChart(sheet, fmt.Sprintf("%s%d", ChartColumn, anchor), &excelize.Chart{
+			Type:       excelize.Bar,
+			Title:      []excelize.RichTextRun{{Text: fmt.Sprintf("Top %d Vulnerable Packages", TopPackageCount)}},
+			VaryColors: &fixedColors,
+			Series: []excelize.ChartSeries{{
+				Name:       fmt.Sprintf("%s!$B$%d", sheet, layout.packages[0]-1),
+				Categories: ref("A", layout.packages[0], layout.packages[1]),
+				Values:     ref("B", layout.packages[0], layout.packages[1]),
+				Fill:       excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{SeverityColor["HIGH"]}},
+			}},
+			Legend:    excelize.ChartLegend{Position: "none"},
+			PlotArea:  excelize.ChartPlotArea{ShowVal: true},
+			XAxis:     excelize.ChartAxis{ReverseOrder: true},
+			YAxis:     excelize.ChartAxis{MajorGridLines: true},
+			Dimension: dimension,
+		}); err != nil {
+			return fmt.Errorf("failed to add package chart: %w", err)
+		}
+	}
+	return nil
+}
+
+// applySeverityTheme sets the workbook theme accents 1-5 to the SeverityColor palette, in Severities order.
+// Pie and doughnut slices take their colors from the theme accents, so this is what makes
+// the severity chart match the rows; everything else in the workbook uses explicit colors.
+func applySeverityTheme(f *excelize.File) {
+	if f.Theme == nil {
+		return
+	}
+	scheme := &f.Theme.ThemeElements.ClrScheme
+	accents := make([]*string, len(model.Severities))
+	if c := scheme.Accent1.SrgbClr; c != nil {
+		accents[0] = c.Val
+	}
+	if c := scheme.Accent2.SrgbClr; c != nil {
+		accents[1] = c.Val
+	}
+	if c := scheme.Accent3.SrgbClr; c != nil {
+		accents[2] = c.Val
+	}
+	if c := scheme.Accent4.SrgbClr; c != nil {
+		accents[3] = c.Val
+	}
+	if c := scheme.Accent5.SrgbClr; c != nil {
+		accents[4] = c.Val
+	}
+	for i, severity := range model.Severities {
+		if accents[i] != nil {
+			*accents[i] = SeverityColor[severity]
+		}
+	}
+}
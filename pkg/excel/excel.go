@@ -3,13 +3,23 @@ package excel
 import (
 	"fmt"
 	"strings"
+	"unicode"
+
 	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
 	"github.com/aquasecurity/trivy/pkg/types"
 	"github.com/xuri/excelize/v2"
+	"trivy-plugin-excel/pkg/exporter"
+	"trivy-plugin-excel/pkg/model"
+	"trivy-plugin-excel/pkg/triage"
+	"trivy-plugin-excel/pkg/utils"
 )
 
 const (
-	VulnReport = "Vulnerability Scan Report"
+	VulnReport   = "Vulnerability Scan Report"
+	FilterReport = "Filters"
+
+	// LinkHeader is the column whose cells link to the row's advisory
+	LinkHeader = "Vulnerability ID"
 )
 
 var (
@@ -28,17 +38,22 @@ var (
 		"UNKNOWN":  "DFE6E9", // Grey
 	}
 
+	// VulnHeaderValues lead with the Artifact column, which is only written for merged reports.
 	VulnHeaderValues = []string{
-		"Target", "Type", "Class", "Vulnerability ID", "Title",
-		"Severity Source", "Severity", "Package Name", "Installed Version", "Path",
-		"Fixed Version", "Status",
+		"Artifact", "Target", "Type", "Class", "Vulnerability ID", "Title",
+		"Severity Source", "Severity", "Severity Order", "CVSS Source", "CVSS v3 Score", "CVSS v3 Vector",
+		"Package Name", "Installed Version", "Path", "Fixed Version", "Status", "Tag",
 	}
 
 	VulnHeaderWidths = map[string]float64{
-		"A": 25, "B": 15, "C": 15, "D": 20, "E": 40,
-		"F": 15, "G": 12, "H": 20, "I": 20, "J": 30,
-		"K": 20, "L": 15,
+		"A": 25, "B": 25, "C": 15, "D": 15, "E": 20, "F": 40,
+		"G": 15, "H": 12, "I": 10, "J": 12, "K": 14, "L": 45,
+		"M": 20, "N": 20, "O": 30, "P": 20, "Q": 15, "R": 25,
 	}
+
+	FilterHeaderValues = []string{"Active Filter"}
+
+	FilterHeaderWidths = map[string]float64{"A": 60}
 )
 
 // sanitize prevents Excel Formula Injection (SECURITY)
@@ -50,119 +65,447 @@ func sanitize(s string) string {
 	return s
 }
 
+func init() {
+	exporter.Register(Exporter{})
+}
+
+// Exporter adapts Export to the exporter.Exporter interface.
+type Exporter struct{}
+
+func (Exporter) Name() string      { return "excel" }
+func (Exporter) Extension() string { return ".xlsx" }
+
+func (Exporter) SupportedOptions() []string {
+	return []string{exporter.OptionBeautify, exporter.OptionShowSecretContext, exporter.OptionCVSSSource, exporter.OptionExcelLayout, exporter.OptionTriage, exporter.OptionPrevious}
+}
+
+func (Exporter) Export(report *model.Report, path string, opts exporter.Options) error {
+	return Export(report, path, opts)
+}
+
 // Export generates an Excel report from the Trivy scan results.
-func Export(report *types.Report, fileName string, beautify bool) error {
+func Export(report *model.Report, fileName string, opts exporter.Options) error {
+	beautify := opts.Beautify
+	if err := CheckLayout(opts.ExcelLayout); err != nil {
+		return err
+	}
+
 	f := excelize.NewFile()
-	
-	// 1. Initialize Sheet and Header
-	// Create the vulnerability report sheet
-	index, err := f.NewSheet(VulnReport)
+	defer f.Close()
+
+	styles, err := newSheetStyles(f, beautify)
 	if err != nil {
-		return fmt.Errorf("failed to create sheet: %w", err)
+		return err
 	}
-	f.SetActiveSheet(index)
-	f.DeleteSheet("Sheet1") // Remove the default empty sheet
 
-	// Create Headers
-	if err := createVulnHeaders(f); err != nil {
-		return err
+	// Triage columns carry forward the notes of the previous workbook, which then must have them too
+	var tr *triageOptions
+	var previous []triage.Entry
+	if opts.Triage || opts.Previous != "" {
+		tr = &triageOptions{}
+	}
+	if opts.Previous != "" {
+		if previous, err = readVulnEntries(opts.Previous); err != nil {
+			return fmt.Errorf("failed to read previous workbook %s: %w", opts.Previous, err)
+		}
+		tr.previous = previousTriage(previous)
 	}
 
-	rowNum := 2
-	hasVuln := false
+	// 1. Lead with the headline numbers and charts of them
+	layout, err := writeSummary(f, report, beautify)
+	if err != nil {
+		return err
+	}
+	if err := addSummaryCharts(f, layout); err != nil {
+		return err
+	}
 
-	// 2. Define Default Style
-	// Basic style with borders and text wrapping
-	defaultStyleID, _ := f.NewStyle(&excelize.Style{
-		Alignment: &excelize.Alignment{WrapText: true, Vertical: "top", Horizontal: "left"},
-		Border: []excelize.Border{
-			{Type: "left", Style: 1, Color: "000000"},
-			{Type: "top", Style: 1, Color: "000000"},
-			{Type: "right", Style: 1, Color: "000000"},
-			{Type: "bottom", Style: 1, Color: "000000"},
-		},
-	})
-
-	// 3. Iterate over results
-	for _, result := range report.Results {
-		if len(result.Vulnerabilities) == 0 {
-			continue
-		}
-		hasVuln = true
-
-		for _, vuln := range result.Vulnerabilities {
-			// Parse vulnerability data (sanitization is applied within parseVulnData)
-			data := parseVulnData(result.Target, result.Type, result.Class, vuln)
-			
-			cell, _ := excelize.CoordinatesToCellName(1, rowNum)
-			if err := f.SetSheetRow(VulnReport, cell, &data); err != nil {
-				return fmt.Errorf("failed to add row %d: %w", rowNum, err)
+	// 2. Fill the sheets: one per change category when diffing, otherwise as many as the layout asks for,
+	// listed on an Index sheet right after the Summary unless there is a single one
+	if report.Diff != nil {
+		for _, change := range model.Changes {
+			if err := writeVulnSheet(f, styles, string(change), tableName(string(change)), tr, report.Merged(), diffVulnRows(report, change, opts.CVSSSources)); err != nil {
+				return err
 			}
-
-			// Apply Row Style (Border + Optional Coloring)
-			startCell := fmt.Sprintf("A%d", rowNum)
-			endCell := fmt.Sprintf("L%d", rowNum)
-
-			if beautify {
-				// If beautify is enabled, apply color based on severity
-				if color, ok := SeverityColor[vuln.Severity]; ok {
-					styleID, _ := f.NewStyle(&excelize.Style{
-						Alignment: &excelize.Alignment{WrapText: true, Vertical: "top", Horizontal: "left"},
-						Border: []excelize.Border{
-							{Type: "left", Style: 1, Color: "000000"},
-							{Type: "top", Style: 1, Color: "000000"},
-							{Type: "right", Style: 1, Color: "000000"},
-							{Type: "bottom", Style: 1, Color: "000000"},
-						},
-						Fill: excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{color}},
-					})
-					f.SetCellStyle(VulnReport, startCell, endCell, styleID)
-				} else {
-					// Fallback to default style if color is not defined
-					f.SetCellStyle(VulnReport, startCell, endCell, defaultStyleID)
-				}
-			} else {
-				// If beautify is disabled, apply borders only
-				f.SetCellStyle(VulnReport, startCell, endCell, defaultStyleID)
+		}
+	}
+	var sheets []vulnSheet
+	if report.Diff == nil {
+		if sheets, err = layoutVulnSheets(report, opts.ExcelLayout, opts.CVSSSources); err != nil {
+			return err
+		}
+		if opts.ExcelLayout != "" && opts.ExcelLayout != LayoutFlat {
+			if _, err := f.NewSheet(IndexReport); err != nil {
+				return fmt.Errorf("failed to create sheet: %w", err)
 			}
+		}
+		for _, s := range sheets {
+			if err := writeVulnSheet(f, styles, s.name, s.table, tr, report.Merged(), s.rows); err != nil {
+				return err
+			}
+		}
+	}
 
-			rowNum++
+	// Vulnerabilities gone since the previous workbook are highlighted on a sheet of their own
+	if rows := collectResolvedRows(report, previous); len(rows) > 0 {
+		if err := writeSheet(f, styles, ResolvedReport, ResolvedHeaderValues, ResolvedHeaderWidths, rows); err != nil {
+			return err
 		}
 	}
 
-	// Save the file even if no vulnerabilities are found (empty report with headers)
-	if hasVuln {
-		return f.SaveAs(fileName)
+	// Other finding kinds get their own sheets, only when present
+	if rows := collectMisconfRows(report); len(rows) > 0 {
+		if err := writeSheet(f, styles, MisconfReport, MisconfHeaderValues, MisconfHeaderWidths, rows); err != nil {
+			return err
+		}
+	}
+	if rows := collectSecretRows(report, opts.ShowSecretContext); len(rows) > 0 {
+		if err := writeSheet(f, styles, SecretReport, SecretHeaderValues, SecretHeaderWidths, rows); err != nil {
+			return err
+		}
+	}
+	if report.HasPackages() {
+		if err := writeSheet(f, styles, InventoryReport, InventoryHeaderValues, InventoryHeaderWidths, collectInventoryRows(report)); err != nil {
+			return err
+		}
+	}
+	if report.HasLicenses() {
+		if err := writeSheet(f, styles, LicenseComplianceReport, LicenseComplianceHeaderValues, LicenseComplianceHeaderWidths, collectLicenseComplianceRows(report)); err != nil {
+			return err
+		}
+		if err := writeSheet(f, styles, LicenseReport, LicenseHeaderValues, LicenseHeaderWidths, collectLicenseRows(report)); err != nil {
+			return err
+		}
+	}
+
+	// Suppressed findings are listed so auditors can see what was accepted
+	if report.HasSuppressed() {
+		if err := writeSheet(f, styles, SuppressedReport, SuppressedHeaderValues, SuppressedHeaderWidths, collectSuppressedRows(report)); err != nil {
+			return err
+		}
+	}
+
+	// Record the active filters so readers know the findings are partial
+	if report.Filter.Active() {
+		if err := writeSheet(f, styles, FilterReport, FilterHeaderValues, FilterHeaderWidths, collectFilterRows(report)); err != nil {
+			return err
+		}
 	}
-	
+
+	if index, _ := f.GetSheetIndex(IndexReport); index >= 0 {
+		if err := writeIndex(f, styles, sheets); err != nil {
+			return err
+		}
+	}
+
+	// Save the file even if no vulnerabilities are found (empty report with headers)
 	return f.SaveAs(fileName)
 }
 
-// createVulnHeaders sets up the header row with styles and column widths.
-func createVulnHeaders(f *excelize.File) error {
-	// Set Header Values
-	if err := f.SetSheetRow(VulnReport, "A1", &VulnHeaderValues); err != nil {
-		return err
+// sheetRow is a parsed sheet row together with the severity used to color it
+// and, for vulnerabilities, the advisory its ID links to.
+type sheetRow struct {
+	data     []interface{}
+	severity string
+	link     string
+	// purl identifies the package of a vulnerability row for the triage columns
+	purl string
+	// key matches a vulnerability row to the previous workbook's (model.VulnKey)
+	key string
+}
+
+// collectFilterRows lists the filters applied to the report, one per row.
+func collectFilterRows(report *model.Report) []sheetRow {
+	var rows []sheetRow
+	for _, filter := range report.Filter.Describe() {
+		rows = append(rows, sheetRow{data: []interface{}{sanitize(filter)}})
+	}
+	return rows
+}
+
+// collectVulnRows parses every vulnerability of the report, grouped by artifact.
+func collectVulnRows(report *model.Report, cvssSources []string) []sheetRow {
+	var rows []sheetRow
+	for ai := range report.Artifacts {
+		artifact := &report.Artifacts[ai]
+		for _, result := range artifact.Results {
+			rows = append(rows, resultVulnRows(report, artifact, result, cvssSources)...)
+		}
+	}
+	return rows
+}
+
+// resultVulnRows parses the vulnerabilities of a single result.
+func resultVulnRows(report *model.Report, artifact *model.Artifact, result types.Result, cvssSources []string) []sheetRow {
+	var rows []sheetRow
+	for _, vuln := range result.Vulnerabilities {
+		// Parse vulnerability data (sanitization is applied within parseVulnData)
+		data := parseVulnData(report.ArtifactLabel(artifact, vuln), result.Target, result.Type, result.Class, vuln, cvssSources, report.Merged())
+		data = append(data, sanitize(report.Tag(artifact.Name, result.Target, vuln)))
+		rows = append(rows, sheetRow{data: data, severity: vuln.Severity, link: vuln.PrimaryURL, purl: purl(vuln), key: model.VulnKey(vuln)})
+	}
+	return rows
+}
+
+// diffVulnRows parses the findings of a single change category.
+func diffVulnRows(report *model.Report, change model.Change, cvssSources []string) []sheetRow {
+	var rows []sheetRow
+	for _, finding := range report.Diff.ByChange(change) {
+		data := parseVulnData(finding.Artifact, finding.Target, finding.Type, finding.Class, finding.Vulnerability, cvssSources, report.Merged())
+		data = append(data, sanitize(report.Tag(finding.Artifact, finding.Target, finding.Vulnerability)))
+		rows = append(rows, sheetRow{data: data, severity: finding.Vulnerability.Severity, link: finding.Vulnerability.PrimaryURL, purl: purl(finding.Vulnerability), key: model.VulnKey(finding.Vulnerability)})
+	}
+	return rows
+}
+
+// purl renders the package URL of a vulnerability, or "" when Trivy did not identify the package.
+func purl(vuln types.DetectedVulnerability) string {
+	if vuln.PkgIdentifier.PURL == nil {
+		return ""
+	}
+	return sanitize(vuln.PkgIdentifier.PURL.String())
+}
+
+// sheetStyles holds the cell styles of a workbook. They are created once per export
+// and shared by every sheet, instead of once per row.
+type sheetStyles struct {
+	header int
+	cell   int
+	link   int
+	date   int
+	// severity and severityLink hold the row fills per severity (empty unless beautifying)
+	severity     map[string]int
+	severityLink map[string]int
+}
+
+// newSheetStyles creates the header, cell and hyperlink styles, with one fill per severity when beautify is set.
+func newSheetStyles(f *excelize.File, beautify bool) (*sheetStyles, error) {
+	borders := []excelize.Border{
+		{Type: "left", Style: 1, Color: "000000"},
+		{Type: "top", Style: 1, Color: "000000"},
+		{Type: "right", Style: 1, Color: "000000"},
+		{Type: "bottom", Style: 1, Color: "000000"},
 	}
+	alignment := &excelize.Alignment{WrapText: true, Vertical: "top", Horizontal: "left"}
+	linkFont := &excelize.Font{Color: "#1265BE", Underline: "single"}
 
-	// Define Header Style (Bold, Dark Gray Background, White Text)
-	headerStyle, _ := f.NewStyle(&excelize.Style{
+	st := &sheetStyles{severity: make(map[string]int), severityLink: make(map[string]int)}
+	var err error
+	// Header Style (Bold, Dark Gray Background, White Text)
+	if st.header, err = f.NewStyle(&excelize.Style{
 		Font:      &excelize.Font{Bold: true, Color: "#FFFFFF"},
 		Fill:      excelize.Fill{Type: "pattern", Color: []string{"#4F4F4F"}, Pattern: 1},
 		Alignment: &excelize.Alignment{Horizontal: "center", Vertical: "center"},
-	})
-	f.SetCellStyle(VulnReport, "A1", "L1", headerStyle)
+	}); err != nil {
+		return nil, fmt.Errorf("failed to create header style: %w", err)
+	}
+	// Basic style with borders and text wrapping
+	if st.cell, err = f.NewStyle(&excelize.Style{Alignment: alignment, Border: borders}); err != nil {
+		return nil, fmt.Errorf("failed to create cell style: %w", err)
+	}
+	if st.link, err = f.NewStyle(&excelize.Style{Font: linkFont, Alignment: alignment, Border: borders}); err != nil {
+		return nil, fmt.Errorf("failed to create link style: %w", err)
+	}
+	dateFormat := "yyyy-mm-dd"
+	if st.date, err = f.NewStyle(&excelize.Style{Alignment: alignment, Border: borders, CustomNumFmt: &dateFormat}); err != nil {
+		return nil, fmt.Errorf("failed to create date style: %w", err)
+	}
+	// Resolved vulnerabilities are highlighted whether or not severities are
+	if st.severity[resolvedRow], err = f.NewStyle(&excelize.Style{
+		Alignment: alignment, Border: borders, Fill: excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{ResolvedColor}},
+	}); err != nil {
+		return nil, fmt.Errorf("failed to create resolved style: %w", err)
+	}
+	if !beautify {
+		return st, nil
+	}
+
+	// Severity fills, one plain and one hyperlink style per color
+	for severity, color := range SeverityColor {
+		fill := excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{color}}
+		if st.severity[severity], err = f.NewStyle(&excelize.Style{Alignment: alignment, Border: borders, Fill: fill}); err != nil {
+			return nil, fmt.Errorf("failed to create %s style: %w", severity, err)
+		}
+		if st.severityLink[severity], err = f.NewStyle(&excelize.Style{Font: linkFont, Alignment: alignment, Border: borders, Fill: fill}); err != nil {
+			return nil, fmt.Errorf("failed to create %s link style: %w", severity, err)
+		}
+	}
+	return st, nil
+}
+
+// row returns the style of a row, falling back to borders only when the severity has no color.
+func (st *sheetStyles) row(severity string) int {
+	if id, ok := st.severity[severity]; ok {
+		return id
+	}
+	return st.cell
+}
+
+// linked returns the hyperlink style matching row(severity).
+func (st *sheetStyles) linked(severity string) int {
+	if id, ok := st.severityLink[severity]; ok {
+		return id
+	}
+	return st.link
+}
+
+// writeSheet creates a sheet with the given header and one styled row per entry.
+func writeSheet(f *excelize.File, st *sheetStyles, sheet string, headers []string, widths map[string]float64, rows []sheetRow) error {
+	return streamSheet(f, st, sheet, headers, widths, rows, "")
+}
+
+// writeVulnSheet writes a vulnerability sheet as a filterable Excel Table with a frozen header row
+// and every Vulnerability ID linked to its advisory, followed by the triage columns unless tr is nil.
+// Only merged reports lead with the Artifact column.
+func writeVulnSheet(f *excelize.File, st *sheetStyles, sheet, table string, tr *triageOptions, merged bool, rows []sheetRow) error {
+	headers, widths := vulnColumns(merged)
+	if tr != nil {
+		headers, widths = triageColumns(headers, widths)
+		return streamSheet(f, st, sheet, headers, widths, withTriage(rows, tr.previous), table)
+	}
+	return streamSheet(f, st, sheet, headers, widths, rows, table)
+}
 
-	// Set Column Widths
+// vulnColumns returns the vulnerability headers and widths, without the Artifact column unless merged.
+func vulnColumns(merged bool) ([]string, map[string]float64) {
+	if merged {
+		return VulnHeaderValues, VulnHeaderWidths
+	}
+	// Every other column moves one to the left
+	widths := make(map[string]float64, len(VulnHeaderWidths))
 	for col, width := range VulnHeaderWidths {
-		f.SetColWidth(VulnReport, col, col, width)
+		if n, _ := excelize.ColumnNameToNumber(col); n > 1 {
+			name, _ := excelize.ColumnNumberToName(n - 1)
+			widths[name] = width
+		}
+	}
+	return VulnHeaderValues[1:], widths
+}
+
+// streamSheet writes a sheet row by row with a StreamWriter, so memory stays flat for large reports.
+// Rows with a link get a HYPERLINK formula in the LinkHeader column, when the sheet has one.
+// Given a (workbook-unique) table name, the header is frozen and the range becomes an Excel Table with autofilter.
+func streamSheet(f *excelize.File, st *sheetStyles, sheet string, headers []string, widths map[string]float64, rows []sheetRow, table string) error {
+	// 1. Initialize Sheet and Stream Writer
+	if _, err := f.NewSheet(sheet); err != nil {
+		return fmt.Errorf("failed to create sheet: %w", err)
+	}
+	if err := addTriageValidation(f, sheet, headers, len(rows)); err != nil {
+		return err
+	}
+	sw, err := f.NewStreamWriter(sheet)
+	if err != nil {
+		return fmt.Errorf("failed to stream sheet: %w", err)
+	}
+
+	// Column widths and panes must precede the rows
+	for col, width := range widths {
+		n, err := excelize.ColumnNameToNumber(col)
+		if err != nil {
+			return err
+		}
+		if err := sw.SetColWidth(n, n, width); err != nil {
+			return err
+		}
+	}
+	if table != "" {
+		if err := sw.SetPanes(&excelize.Panes{Freeze: true, YSplit: 1, TopLeftCell: "A2", ActivePane: "bottomLeft"}); err != nil {
+			return fmt.Errorf("failed to freeze header: %w", err)
+		}
+	}
+
+	// 2. Write the header
+	linkIndex := -1
+	header := make([]interface{}, len(headers))
+	for i, h := range headers {
+		header[i] = excelize.Cell{StyleID: st.header, Value: h}
+		if h == LinkHeader {
+			linkIndex = i
+		}
+	}
+	if err := sw.SetRow("A1", header); err != nil {
+		return fmt.Errorf("failed to add header: %w", err)
+	}
+
+	// 3. Write the rows (Border + Optional Coloring; triage inputs keep their own style)
+	columnStyles := triageStyles(st, headers)
+	for i, r := range rows {
+		rowNum := i + 2
+		style := st.row(r.severity)
+		cells := make([]interface{}, len(r.data))
+		for j, value := range r.data {
+			if id, ok := columnStyles[j]; ok {
+				cells[j] = excelize.Cell{StyleID: id, Value: value}
+				continue
+			}
+			cells[j] = excelize.Cell{StyleID: style, Value: value}
+		}
+		if r.link != "" && linkIndex >= 0 && linkIndex < len(cells) {
+			if formula, ok := hyperlinkFormula(r.link, fmt.Sprint(r.data[linkIndex])); ok {
+				cells[linkIndex] = excelize.Cell{StyleID: st.linked(r.severity), Formula: formula, Value: r.data[linkIndex]}
+			}
+		}
+		cell, _ := excelize.CoordinatesToCellName(1, rowNum)
+		if err := sw.SetRow(cell, cells); err != nil {
+			return fmt.Errorf("failed to add row %d: %w", rowNum, err)
+		}
 	}
-	return nil
+
+	// 4. Table with autofilter over the header and rows (a table needs at least one data row)
+	if table != "" {
+		lastRow := len(rows) + 1
+		if lastRow < 2 {
+			lastRow = 2
+		}
+		noStripes := false
+		if err := sw.AddTable(&excelize.Table{
+			Range:          fmt.Sprintf("A1:%s%d", lastColumn(headers), lastRow),
+			Name:           table,
+			StyleName:      "TableStyleLight1",
+			ShowRowStripes: &noStripes,
+		}); err != nil {
+			return fmt.Errorf("failed to add table: %w", err)
+		}
+	}
+	return sw.Flush()
+}
+
+// hyperlinkFormula builds a HYPERLINK formula showing text and opening url.
+// ok is false when either does not fit Excel's 255 character limit for formula strings.
+func hyperlinkFormula(url, text string) (string, bool) {
+	if len(url) > 255 || len(text) > 255 {
+		return "", false
+	}
+	quote := func(s string) string { return `"` + strings.ReplaceAll(s, `"`, `""`) + `"` }
+	return "HYPERLINK(" + quote(url) + "," + quote(text) + ")", true
+}
+
+// tableName derives a valid, workbook-unique Excel table name from a sheet name.
+func tableName(sheet string) string {
+	var b strings.Builder
+	for _, r := range sheet {
+		if r < 128 && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			b.WriteRune(r)
+		}
+	}
+	if b.Len() == 0 || !unicode.IsLetter(rune(b.String()[0])) {
+		return "Table" + b.String()
+	}
+	return b.String()
+}
+
+// lastColumn returns the column letter of the last header.
+func lastColumn(headers []string) string {
+	col, _ := excelize.ColumnNumberToName(len(headers))
+	return col
 }
 
 // parseVulnData prepares a row of data for the Excel sheet.
 // It converts types and sanitizes inputs to prevent injection attacks.
-func parseVulnData(target string, rType ftypes.TargetType, rClass types.ResultClass, vuln types.DetectedVulnerability) []interface{} {
+// The CVSS score is kept numeric (or empty) so the column can be sorted and filtered,
+// and the severity order lets the table sort CRITICAL first instead of alphabetically.
+// The artifact name leads the row only withArtifact, matching vulnColumns.
+func parseVulnData(artifactName, target string, rType ftypes.TargetType, rClass types.ResultClass, vuln types.DetectedVulnerability, cvssSources []string, withArtifact bool) []interface{} {
 	classStr := string(rClass)
 	if v, ok := ResultClass[rClass]; ok {
 		classStr = v
@@ -171,9 +514,15 @@ func parseVulnData(target string, rType ftypes.TargetType, rClass types.ResultCl
 	// Safely convert status to string (handling potential enum types)
 	statusStr := fmt.Sprint(vuln.Status)
 
+	var cvssScore interface{}
+	cvssSource, score, cvssVector, ok := utils.PreferredCVSS(vuln, cvssSources)
+	if ok {
+		cvssScore = score
+	}
+
 	// IMPORTANT: Wrap all string fields with sanitize() to prevent CSV/Excel Injection.
 	// Returning []interface{} ensures better compatibility with excelize's SetSheetRow.
-	return []interface{}{
+	data := []interface{}{
 		sanitize(target),
 		sanitize(string(rType)),
 		sanitize(classStr),
@@ -181,10 +530,18 @@ func parseVulnData(target string, rType ftypes.TargetType, rClass types.ResultCl
 		sanitize(vuln.Title),
 		sanitize(string(vuln.SeveritySource)),
 		sanitize(vuln.Severity),
+		model.SeverityOrder(vuln.Severity),
+		sanitize(cvssSource),
+		cvssScore,
+		sanitize(cvssVector),
 		sanitize(vuln.PkgName),
 		sanitize(vuln.InstalledVersion),
 		sanitize(vuln.PkgPath),
 		sanitize(vuln.FixedVersion),
 		sanitize(statusStr),
 	}
-}
\ No newline at end of file
+	if withArtifact {
+		data = append([]interface{}{sanitize(artifactName)}, data...)
+	}
+	return data
+}
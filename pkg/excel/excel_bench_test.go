@@ -0,0 +1,48 @@
+package excel
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/aquasecurity/trivy/pkg/types"
+	"trivy-plugin-excel/pkg/exporter"
+	"trivy-plugin-excel/pkg/model"
+)
+
+// benchReport builds a report with n vulnerabilities spread over ten targets and every severity.
+func benchReport(n int) *model.Report {
+	results := make(types.Results, 10)
+	for i := range results {
+		results[i] = types.Result{Target: fmt.Sprintf("app/target-%d/package-lock.json", i), Class: types.ClassLangPkg, Type: "npm"}
+	}
+	for i := 0; i < n; i++ {
+		vuln := types.DetectedVulnerability{
+			VulnerabilityID:  fmt.Sprintf("CVE-2024-%05d", i),
+			PkgName:          fmt.Sprintf("package-%d", i%500),
+			InstalledVersion: "1.0.0",
+			FixedVersion:     "1.0.1",
+			PrimaryURL:       fmt.Sprintf("https://avd.aquasec.com/nvd/cve-2024-%05d", i),
+		}
+		vuln.Title = "Prototype pollution in a widely used helper library allows property injection"
+		vuln.Severity = model.Severities[i%len(model.Severities)]
+		results[i%len(results)].Vulnerabilities = append(results[i%len(results)].Vulnerabilities, vuln)
+	}
+	return model.New(&types.Report{ArtifactName: "bench", Results: results})
+}
+
+// BenchmarkExport writes workbooks of growing size; time and allocated bytes per op should grow roughly linearly.
+func BenchmarkExport(b *testing.B) {
+	for _, n := range []int{1000, 10000, 50000} {
+		report := benchReport(n)
+		b.Run(fmt.Sprintf("findings=%d", n), func(b *testing.B) {
+			path := filepath.Join(b.TempDir(), "report.xlsx")
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if err := Export(report, path, exporter.Options{Beautify: true}); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package excel
+
+import (
+	"strings"
+
+	"trivy-plugin-excel/pkg/model"
+	"trivy-plugin-excel/pkg/utils"
+)
+
+const (
+	InventoryReport = "Inventory"
+)
+
+var (
+	InventoryHeaderValues = []string{
+		"Artifact", "Target", "Class", "Package Name", "Version", "Licenses",
+		"PURL", "Layer DiffID", "File Path", "Vulnerabilities", "Highest Severity",
+	}
+
+	InventoryHeaderWidths = map[string]float64{
+		"A": 25, "B": 25, "C": 15, "D": 25, "E": 20, "F": 20,
+		"G": 45, "H": 30, "I": 30, "J": 15, "K": 15,
+	}
+)
+
+// collectInventoryRows lists every package of the report with its vulnerability count.
+// Rows are colored by the package's highest severity, so clean packages stay uncolored.
+func collectInventoryRows(report *model.Report) []sheetRow {
+	var rows []sheetRow
+	for _, item := range report.Inventory() {
+		pkg := item.Package
+		purl := ""
+		if pkg.Identifier.PURL != nil {
+			purl = pkg.Identifier.PURL.String()
+		}
+		data := []interface{}{
+			sanitize(item.Artifact),
+			sanitize(item.Target),
+			sanitize(utils.SetResultClass(item.Class)),
+			sanitize(pkg.Name),
+			sanitize(model.PackageVersion(pkg)),
+			sanitize(strings.Join(pkg.Licenses, ", ")),
+			sanitize(purl),
+			sanitize(pkg.Layer.DiffID),
+			sanitize(pkg.FilePath),
+			item.Vulnerabilities,
+			sanitize(item.MaxSeverity),
+		}
+		rows = append(rows, sheetRow{data: data, severity: item.MaxSeverity})
+	}
+	return rows
+}
@@ -0,0 +1,191 @@
+package excel
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aquasecurity/trivy/pkg/types"
+	"github.com/xuri/excelize/v2"
+	"trivy-plugin-excel/pkg/model"
+	"trivy-plugin-excel/pkg/utils"
+)
+
+// Layouts of the vulnerability sheets
+const (
+	LayoutFlat      = "flat"
+	LayoutPerTarget = "per-target"
+	LayoutPerClass  = "per-class"
+
+	IndexReport = "Index"
+
+	// MaxSheetName is the longest sheet name Excel accepts
+	MaxSheetName = 31
+)
+
+var (
+	Layouts = []string{LayoutFlat, LayoutPerTarget, LayoutPerClass}
+
+	IndexHeaderValues = []string{"Sheet", "Contents", "Vulnerabilities"}
+
+	IndexHeaderWidths = map[string]float64{"A": 35, "B": 70, "C": 16}
+
+	// fixedSheets are the names of the sheets other than the vulnerability sheets
+	fixedSheets = []string{
+		SummaryReport, IndexReport, MisconfReport, SecretReport, InventoryReport,
+		LicenseComplianceReport, LicenseReport, SuppressedReport, FilterReport, ResolvedReport,
+		"History", // reserved by Excel
+	}
+
+	// invalidSheetChars are the characters Excel rejects in sheet names
+	invalidSheetChars = strings.NewReplacer("[", "_", "]", "_", ":", "_", "*", "_", "?", "_", "/", "_", "\\", "_")
+)
+
+// vulnSheet is one vulnerability sheet of a layout.
+type vulnSheet struct {
+	name     string
+	table    string
+	contents string
+	rows     []sheetRow
+}
+
+// layoutVulnSheets splits the vulnerabilities into the sheets of a layout: a single sheet when flat,
+// one per scan target, or one per result class (OS packages, language packages, configuration).
+// Sheet names are made valid and unique; a report without vulnerabilities still gets one empty sheet.
+func layoutVulnSheets(report *model.Report, layout string, cvssSources []string) ([]vulnSheet, error) {
+	var sheets []vulnSheet
+	switch layout {
+	case "", LayoutFlat:
+		return []vulnSheet{{name: VulnReport, table: tableName(VulnReport), contents: "All vulnerabilities", rows: collectVulnRows(report, cvssSources)}}, nil
+	case LayoutPerTarget:
+		for ai := range report.Artifacts {
+			artifact := &report.Artifacts[ai]
+			for _, result := range artifact.Results {
+				if len(result.Vulnerabilities) == 0 {
+					continue
+				}
+				contents := result.Target + " (" + utils.SetResultClass(result.Class) + ")"
+				if report.Merged() {
+					contents = artifact.Name + ": " + contents
+				}
+				sheets = append(sheets, vulnSheet{name: result.Target, contents: contents, rows: resultVulnRows(report, artifact, result, cvssSources)})
+			}
+		}
+	case LayoutPerClass:
+		byClass := make(map[types.ResultClass]int)
+		for ai := range report.Artifacts {
+			artifact := &report.Artifacts[ai]
+			for _, result := range artifact.Results {
+				if len(result.Vulnerabilities) == 0 {
+					continue
+				}
+				i, ok := byClass[result.Class]
+				if !ok {
+					i = len(sheets)
+					byClass[result.Class] = i
+					name := utils.SetResultClass(result.Class)
+					sheets = append(sheets, vulnSheet{name: name, contents: name})
+				}
+				sheets[i].rows = append(sheets[i].rows, resultVulnRows(report, artifact, result, cvssSources)...)
+			}
+		}
+	default:
+		return nil, CheckLayout(layout)
+	}
+	if len(sheets) == 0 {
+		return []vulnSheet{{name: VulnReport, table: tableName(VulnReport), contents: "No vulnerabilities"}}, nil
+	}
+
+	used := make(map[string]bool)
+	for _, name := range fixedSheets {
+		used[strings.ToLower(name)] = true
+	}
+	for i := range sheets {
+		sheets[i].name = uniqueSheetName(sheets[i].name, used)
+		// Table names derived from sheet names could collide or read as cell references
+		sheets[i].table = fmt.Sprintf("Vulnerabilities%d", i+1)
+	}
+	return sheets, nil
+}
+
+// CheckLayout rejects layouts other than Layouts; empty means flat.
+// The CLI checks the flag up front, so a typo fails before any format is written.
+func CheckLayout(layout string) error {
+	if layout == "" {
+		return nil
+	}
+	for _, l := range Layouts {
+		if layout == l {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown Excel layout %q (want one of %s)", layout, strings.Join(Layouts, ", "))
+}
+
+// uniqueSheetName turns s into a valid sheet name not yet in used (lower-cased, as Excel compares
+// names case-insensitively) and records it. Long names keep their end, the most specific part of a path;
+// duplicates get a " (2)", " (3)", ... suffix.
+func uniqueSheetName(s string, used map[string]bool) string {
+	name := strings.TrimSpace(strings.Trim(invalidSheetChars.Replace(s), "'"))
+	if name == "" {
+		name = "Sheet"
+	}
+	if runes := []rune(name); len(runes) > MaxSheetName {
+		name = "…" + string(runes[len(runes)-MaxSheetName+1:])
+	}
+
+	candidate := name
+	for n := 2; used[strings.ToLower(candidate)]; n++ {
+		suffix := fmt.Sprintf(" (%d)", n)
+		base := []rune(name)
+		if len(base)+len(suffix) > MaxSheetName {
+			base = base[:MaxSheetName-len(suffix)]
+		}
+		candidate = string(base) + suffix
+	}
+	used[strings.ToLower(candidate)] = true
+	return candidate
+}
+
+// writeIndex fills the Index sheet with a link to every sheet after it, describing the vulnerability sheets.
+// The sheet is created up front so it follows the Summary, and filled once all other sheets exist.
+func writeIndex(f *excelize.File, st *sheetStyles, sheets []vulnSheet) error {
+	vulnSheets := make(map[string]vulnSheet)
+	for _, s := range sheets {
+		vulnSheets[s.name] = s
+	}
+
+	for col, width := range IndexHeaderWidths {
+		f.SetColWidth(IndexReport, col, col, width)
+	}
+	header := make([]interface{}, len(IndexHeaderValues))
+	for i, h := range IndexHeaderValues {
+		header[i] = h
+	}
+	if err := f.SetSheetRow(IndexReport, "A1", &header); err != nil {
+		return fmt.Errorf("failed to add index header: %w", err)
+	}
+	f.SetCellStyle(IndexReport, "A1", fmt.Sprintf("%s1", lastColumn(IndexHeaderValues)), st.header)
+
+	rowNum := 2
+	for _, name := range f.GetSheetList() {
+		if name == SummaryReport || name == IndexReport {
+			continue
+		}
+		values := []interface{}{name, "", ""}
+		if s, ok := vulnSheets[name]; ok {
+			values = []interface{}{name, sanitize(s.contents), len(s.rows)}
+		}
+		cell, _ := excelize.CoordinatesToCellName(1, rowNum)
+		if err := f.SetSheetRow(IndexReport, cell, &values); err != nil {
+			return fmt.Errorf("failed to add index row %d: %w", rowNum, err)
+		}
+		f.SetCellStyle(IndexReport, cell, fmt.Sprintf("%s%d", lastColumn(IndexHeaderValues), rowNum), st.cell)
+		location := "'" + strings.ReplaceAll(name, "'", "''") + "'!A1"
+		if err := f.SetCellHyperLink(IndexReport, cell, location, "Location"); err != nil {
+			return fmt.Errorf("failed to link sheet %s: %w", name, err)
+		}
+		f.SetCellStyle(IndexReport, cell, cell, st.link)
+		rowNum++
+	}
+	return nil
+}
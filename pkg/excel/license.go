@@ -0,0 +1,66 @@
+package excel
+
+import (
+	"strings"
+
+	"trivy-plugin-excel/pkg/model"
+)
+
+const (
+	LicenseReport           = "Licenses"
+	LicenseComplianceReport = "License Compliance"
+)
+
+var (
+	LicenseHeaderValues = []string{
+		"Artifact", "Target", "Severity", "Category", "Package Name",
+		"File Path", "License", "Confidence", "Link",
+	}
+
+	LicenseHeaderWidths = map[string]float64{
+		"A": 25, "B": 25, "C": 12, "D": 15, "E": 25,
+		"F": 30, "G": 25, "H": 12, "I": 40,
+	}
+
+	LicenseComplianceHeaderValues = []string{"Category", "Findings", "Licenses"}
+
+	LicenseComplianceHeaderWidths = map[string]float64{"A": 20, "B": 12, "C": 80}
+)
+
+// collectLicenseRows parses every license finding of the report, grouped by artifact.
+func collectLicenseRows(report *model.Report) []sheetRow {
+	var rows []sheetRow
+	for _, artifact := range report.Artifacts {
+		for _, result := range artifact.Results {
+			for _, license := range result.Licenses {
+				data := []interface{}{
+					sanitize(artifact.Name),
+					sanitize(result.Target),
+					sanitize(license.Severity),
+					sanitize(string(license.Category)),
+					sanitize(license.PkgName),
+					sanitize(license.FilePath),
+					sanitize(license.Name),
+					license.Confidence,
+					sanitize(license.Link),
+				}
+				rows = append(rows, sheetRow{data: data, severity: license.Severity})
+			}
+		}
+	}
+	return rows
+}
+
+// collectLicenseComplianceRows counts license findings per category, most restrictive first.
+func collectLicenseComplianceRows(report *model.Report) []sheetRow {
+	var rows []sheetRow
+	for _, count := range report.LicenseSummary() {
+		data := []interface{}{
+			string(count.Category),
+			count.Findings,
+			sanitize(strings.Join(count.Licenses, ", ")),
+		}
+		rows = append(rows, sheetRow{data: data, severity: model.LicenseSeverity(count.Category)})
+	}
+	return rows
+}
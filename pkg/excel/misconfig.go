@@ -0,0 +1,61 @@
+package excel
+
+import (
+	"github.com/aquasecurity/trivy/pkg/types"
+	"trivy-plugin-excel/pkg/model"
+	"trivy-plugin-excel/pkg/utils"
+)
+
+const (
+	MisconfReport = "Misconfigurations"
+)
+
+var (
+	MisconfHeaderValues = []string{
+		"Artifact", "Target", "Class", "Check Type", "ID", "AVD ID", "Title",
+		"Severity", "Status", "Resolution", "Resource", "Provider", "Service",
+		"Lines", "Primary URL",
+	}
+
+	MisconfHeaderWidths = map[string]float64{
+		"A": 25, "B": 25, "C": 15, "D": 20, "E": 12, "F": 14, "G": 40,
+		"H": 12, "I": 12, "J": 40, "K": 25, "L": 15, "M": 15,
+		"N": 10, "O": 40,
+	}
+)
+
+// collectMisconfRows parses every misconfiguration of the report, grouped by artifact.
+func collectMisconfRows(report *model.Report) []sheetRow {
+	var rows []sheetRow
+	for _, artifact := range report.Artifacts {
+		for _, result := range artifact.Results {
+			for _, misconf := range result.Misconfigurations {
+				data := parseMisconfData(artifact.Name, result, misconf)
+				rows = append(rows, sheetRow{data: data, severity: misconf.Severity})
+			}
+		}
+	}
+	return rows
+}
+
+// parseMisconfData prepares a sanitized misconfiguration row for the Excel sheet.
+func parseMisconfData(artifactName string, result types.Result, misconf types.DetectedMisconfiguration) []interface{} {
+	cause := misconf.CauseMetadata
+	return []interface{}{
+		sanitize(artifactName),
+		sanitize(result.Target),
+		sanitize(utils.SetResultClass(result.Class)),
+		sanitize(misconf.Type),
+		sanitize(misconf.ID),
+		sanitize(misconf.AVDID),
+		sanitize(misconf.Title),
+		sanitize(misconf.Severity),
+		sanitize(string(misconf.Status)),
+		sanitize(misconf.Resolution),
+		sanitize(cause.Resource),
+		sanitize(cause.Provider),
+		sanitize(cause.Service),
+		utils.FormatLineRange(cause.StartLine, cause.EndLine),
+		sanitize(misconf.PrimaryURL),
+	}
+}
@@ -0,0 +1,83 @@
+package excel
+
+import (
+	"trivy-plugin-excel/pkg/model"
+	"trivy-plugin-excel/pkg/triage"
+)
+
+const (
+	ResolvedReport = "Resolved Since Previous"
+
+	// ResolvedColor fills the rows of vulnerabilities resolved since the previous workbook (green)
+	ResolvedColor = "C6EFCE"
+
+	// resolvedRow is the sheetRow severity that selects the resolved fill
+	resolvedRow = "RESOLVED"
+)
+
+var (
+	ResolvedHeaderValues = []string{
+		"Artifact", "Target", LinkHeader, "Package Name", "Installed Version", "Path",
+		OwnerHeader, DecisionHeader, DueDateHeader, CommentHeader,
+	}
+
+	ResolvedHeaderWidths = map[string]float64{
+		"A": 25, "B": 30, "C": 20, "D": 20, "E": 20, "F": 30,
+		"G": 18, "H": 16, "I": 14, "J": 50,
+	}
+)
+
+// triageOptions controls the triage columns of the vulnerability sheets.
+type triageOptions struct {
+	// previous holds the entries of the previous workbook by key, whose triage columns are carried forward
+	previous map[string]triage.Entry
+}
+
+// previousTriage indexes the vulnerabilities of a previous workbook by key.
+// A key listed on several sheets keeps the first entry a reviewer filled in.
+func previousTriage(entries []triage.Entry) map[string]triage.Entry {
+	previous := make(map[string]triage.Entry)
+	for _, e := range entries {
+		if old, ok := previous[e.Key()]; ok && (old.Triaged() || !e.Triaged()) {
+			continue
+		}
+		previous[e.Key()] = e
+	}
+	return previous
+}
+
+// collectResolvedRows lists the vulnerabilities of the previous workbook the report no longer has, with their
+// triage notes. Suppressed vulnerabilities were accepted rather than resolved, and vulnerabilities the active
+// filter hides are still found by the scan; both are left out.
+func collectResolvedRows(report *model.Report, entries []triage.Entry) []sheetRow {
+	current := make(map[string]bool)
+	for _, result := range report.Results() {
+		for _, vuln := range result.Vulnerabilities {
+			current[model.VulnKey(vuln)] = true
+		}
+	}
+	for _, s := range report.Suppressed {
+		current[s.ID+"|"+s.Package] = true
+	}
+
+	var rows []sheetRow
+	for _, e := range entries {
+		key := e.Key()
+		if current[key] || current[e.VulnerabilityID+"|"+e.Package] || report.Hidden(key) {
+			continue
+		}
+		current[key] = true
+
+		data := []interface{}{
+			sanitize(e.Artifact),
+			sanitize(e.Target),
+			sanitize(e.VulnerabilityID),
+			sanitize(e.Package),
+			sanitize(e.InstalledVersion),
+			sanitize(e.Path),
+		}
+		data = append(data, triageCells(e)...)
+		rows = append(rows, sheetRow{data: data, severity: resolvedRow})
+	}
+	return rows
+}
@@ -0,0 +1,49 @@
+package excel
+
+import (
+	"trivy-plugin-excel/pkg/model"
+	"trivy-plugin-excel/pkg/utils"
+)
+
+const (
+	SecretReport = "Secrets"
+)
+
+var (
+	SecretHeaderValues = []string{
+		"Artifact", "Target", "Rule ID", "Category", "Severity", "Title",
+		"Start Line", "End Line", "Match", "Code",
+	}
+
+	SecretHeaderWidths = map[string]float64{
+		"A": 25, "B": 30, "C": 25, "D": 15, "E": 12, "F": 35,
+		"G": 10, "H": 10, "I": 40, "J": 50,
+	}
+)
+
+// collectSecretRows parses every secret finding of the report, grouped by artifact.
+// Matches and code snippets are masked unless showContext is set.
+func collectSecretRows(report *model.Report, showContext bool) []sheetRow {
+	var rows []sheetRow
+	for _, artifact := range report.Artifacts {
+		for _, result := range artifact.Results {
+			for _, secret := range result.Secrets {
+				match, code := utils.SecretContext(secret, showContext)
+				data := []interface{}{
+					sanitize(artifact.Name),
+					sanitize(result.Target),
+					sanitize(secret.RuleID),
+					sanitize(string(secret.Category)),
+					sanitize(secret.Severity),
+					sanitize(secret.Title),
+					secret.StartLine,
+					secret.EndLine,
+					sanitize(match),
+					sanitize(code),
+				}
+				rows = append(rows, sheetRow{data: data, severity: secret.Severity})
+			}
+		}
+	}
+	return rows
+}
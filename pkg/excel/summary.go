@@ -0,0 +1,207 @@
+package excel
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+	"trivy-plugin-excel/pkg/model"
+	"trivy-plugin-excel/pkg/utils"
+)
+
+const (
+	SummaryReport = "Summary"
+
+	// TopPackageCount is how many of the most vulnerable packages the summary lists
+	TopPackageCount = 10
+)
+
+var (
+	ArtifactHeaderValues = []string{"Artifact", "Type", "OS", "Image Digest", "Scan Time"}
+
+	TargetHeaderValues = append(append([]string{"Target"}, model.Severities...), "Total")
+
+	SummaryHeaderWidths = map[string]float64{
+		"A": 45, "B": 18, "C": 18, "D": 45, "E": 28, "F": 12, "G": 12,
+	}
+)
+
+// summaryLayout records the first and last row of each data block of the Summary sheet,
+// so charts can reference them. A block without data has a last row before its first.
+type summaryLayout struct {
+	severities [2]int
+	targets    [2]int
+	packages   [2]int
+	// total is the number of vulnerabilities counted
+	total int
+}
+
+// writeSummary creates the Summary sheet: artifact metadata, severity totals,
+// a target × severity matrix and the packages with the most vulnerabilities.
+func writeSummary(f *excelize.File, report *model.Report, beautify bool) (summaryLayout, error) {
+	var layout summaryLayout
+	// The workbook's default sheet becomes the Summary, so it is first and active
+	// without re-reading the streamed sheets as DeleteSheet or SetActiveSheet would
+	sheet := SummaryReport
+	if err := f.SetSheetName(f.GetSheetName(0), sheet); err != nil {
+		return layout, fmt.Errorf("failed to create sheet: %w", err)
+	}
+	for col, width := range SummaryHeaderWidths {
+		f.SetColWidth(sheet, col, col, width)
+	}
+
+	// 1. Define Styles (title, section heading, table header, bordered cells and severity fills)
+	titleStyle, _ := f.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true, Size: 14}})
+	sectionStyle, _ := f.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true, Size: 12}})
+	headerStyle, _ := f.NewStyle(&excelize.Style{
+		Font:      &excelize.Font{Bold: true, Color: "#FFFFFF"},
+		Fill:      excelize.Fill{Type: "pattern", Color: []string{"#4F4F4F"}, Pattern: 1},
+		Alignment: &excelize.Alignment{Horizontal: "center", Vertical: "center"},
+	})
+	borders := []excelize.Border{
+		{Type: "left", Style: 1, Color: "000000"},
+		{Type: "top", Style: 1, Color: "000000"},
+		{Type: "right", Style: 1, Color: "000000"},
+		{Type: "bottom", Style: 1, Color: "000000"},
+	}
+	cellStyle, _ := f.NewStyle(&excelize.Style{
+		Alignment: &excelize.Alignment{WrapText: true, Vertical: "top", Horizontal: "left"},
+		Border:    borders,
+	})
+	totalStyle, _ := f.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}, Border: borders})
+	severityStyles := make(map[string]int)
+	for severity, color := range SeverityColor {
+		style := &excelize.Style{Font: &excelize.Font{Bold: true}, Border: borders}
+		if beautify {
+			style.Fill = excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{color}}
+		}
+		severityStyles[severity], _ = f.NewStyle(style)
+	}
+
+	// 2. Write the rows top to bottom
+	rowNum := 1
+	addRow := func(values []interface{}, style int) error {
+		cell, _ := excelize.CoordinatesToCellName(1, rowNum)
+		if err := f.SetSheetRow(sheet, cell, &values); err != nil {
+			return fmt.Errorf("failed to add row %d: %w", rowNum, err)
+		}
+		if style != 0 {
+			endCol, _ := excelize.ColumnNumberToName(len(values))
+			f.SetCellStyle(sheet, cell, fmt.Sprintf("%s%d", endCol, rowNum), style)
+		}
+		rowNum++
+		return nil
+	}
+	addHeading := func(title string, headers []string) error {
+		rowNum++
+		if err := addRow([]interface{}{title}, sectionStyle); err != nil {
+			return err
+		}
+		values := make([]interface{}, len(headers))
+		for i, h := range headers {
+			values[i] = h
+		}
+		return addRow(values, headerStyle)
+	}
+
+	if err := addRow([]interface{}{"Trivy Scan Summary"}, titleStyle); err != nil {
+		return layout, err
+	}
+
+	// --- Artifacts ---
+	if err := addHeading("Artifacts", ArtifactHeaderValues); err != nil {
+		return layout, err
+	}
+	for _, a := range report.Artifacts {
+		if err := addRow(artifactSummary(a), cellStyle); err != nil {
+			return layout, err
+		}
+	}
+
+	// --- Severity totals ---
+	if err := addHeading("Vulnerabilities by Severity", []string{"Severity", "Count"}); err != nil {
+		return layout, err
+	}
+	totals := report.SeverityTotals()
+	total := 0
+	layout.severities[0] = rowNum
+	for _, severity := range model.Severities {
+		total += totals[severity]
+		if err := addRow([]interface{}{severity, totals[severity]}, severityStyles[severity]); err != nil {
+			return layout, err
+		}
+	}
+	layout.severities[1] = rowNum - 1
+	layout.total = total
+	if err := addRow([]interface{}{"Total", total}, totalStyle); err != nil {
+		return layout, err
+	}
+
+	// --- Target × severity matrix ---
+	if err := addHeading("Vulnerabilities by Target", TargetHeaderValues); err != nil {
+		return layout, err
+	}
+	layout.targets[0] = rowNum
+	for _, s := range report.TargetSummaries() {
+		target := s.Target
+		if report.Merged() {
+			target = s.Artifact + ": " + target
+		}
+		values := []interface{}{sanitize(target)}
+		for _, severity := range model.Severities {
+			values = append(values, s.Counts[severity])
+		}
+		values = append(values, s.Total)
+		if err := addRow(values, cellStyle); err != nil {
+			return layout, err
+		}
+	}
+
+	layout.targets[1] = rowNum - 1
+
+	// --- Top packages ---
+	if err := addHeading("Top Vulnerable Packages", []string{"Package", "Vulnerabilities"}); err != nil {
+		return layout, err
+	}
+	packages := utils.Sort(report.PackageCounts())
+	if len(packages) > TopPackageCount {
+		packages = packages[:TopPackageCount]
+	}
+	layout.packages[0] = rowNum
+	for _, pkg := range packages {
+		count, _ := strconv.Atoi(pkg[1])
+		if err := addRow([]interface{}{sanitize(pkg[0]), count}, cellStyle); err != nil {
+			return layout, err
+		}
+	}
+	layout.packages[1] = rowNum - 1
+	return layout, nil
+}
+
+// artifactSummary describes a scanned artifact: name, type, OS, image digest and scan time.
+func artifactSummary(a model.Artifact) []interface{} {
+	osName := ""
+	if a.Metadata.OS != nil {
+		osName = strings.TrimSpace(string(a.Metadata.OS.Family) + " " + a.Metadata.OS.Name)
+	}
+
+	digest := strings.Join(a.Metadata.RepoDigests, ", ")
+	if digest == "" {
+		digest = a.Metadata.ImageID
+	}
+
+	var createdAt *time.Time
+	if !a.CreatedAt.IsZero() {
+		createdAt = &a.CreatedAt
+	}
+
+	return []interface{}{
+		sanitize(a.Name),
+		utils.SetArtifactType(a.Type),
+		sanitize(osName),
+		sanitize(digest),
+		utils.FormatTime(createdAt),
+	}
+}
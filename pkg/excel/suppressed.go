@@ -0,0 +1,42 @@
+package excel
+
+import (
+	"trivy-plugin-excel/pkg/model"
+)
+
+const (
+	SuppressedReport = "Suppressed"
+)
+
+var (
+	SuppressedHeaderValues = []string{
+		"Artifact", "Target", "Finding Type", "ID", "Package", "Severity",
+		"Status", "Justification", "Source", "Expires",
+	}
+
+	SuppressedHeaderWidths = map[string]float64{
+		"A": 25, "B": 30, "C": 18, "D": 22, "E": 20, "F": 12,
+		"G": 18, "H": 50, "I": 30, "J": 20,
+	}
+)
+
+// collectSuppressedRows lists the findings accepted by an ignore file or VEX statement, with their justification.
+func collectSuppressedRows(report *model.Report) []sheetRow {
+	var rows []sheetRow
+	for _, s := range report.Suppressed {
+		data := []interface{}{
+			sanitize(s.Artifact),
+			sanitize(s.Target),
+			sanitize(string(s.Type)),
+			sanitize(s.ID),
+			sanitize(s.Package),
+			sanitize(s.Severity),
+			sanitize(string(s.Status)),
+			sanitize(s.Statement),
+			sanitize(s.Source),
+			s.Expires(),
+		}
+		rows = append(rows, sheetRow{data: data, severity: s.Severity})
+	}
+	return rows
+}
@@ -0,0 +1,242 @@
+package excel
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+	"trivy-plugin-excel/pkg/model"
+	"trivy-plugin-excel/pkg/triage"
+)
+
+// Triage column headers, read back by ReadTriage
+const (
+	PURLHeader     = "Package URL"
+	OwnerHeader    = "Owner"
+	DecisionHeader = "Decision"
+	DueDateHeader  = "Due Date"
+	CommentHeader  = "Comment"
+
+	// lastExcelDate is 9999-12-31, the latest date Excel can represent
+	lastExcelDate = 2958465
+)
+
+var (
+	// TriageHeaderValues follow VulnHeaderValues when triage columns are enabled.
+	// The package URL identifies the package when the decisions are imported.
+	TriageHeaderValues = []string{PURLHeader, OwnerHeader, DecisionHeader, DueDateHeader, CommentHeader}
+
+	// TriageHeaderWidths are in the order of TriageHeaderValues, whose columns depend on the vulnerability columns before them.
+	TriageHeaderWidths = []float64{40, 18, 16, 14, 50}
+)
+
+// triageColumns returns the given vulnerability headers and widths followed by the triage columns.
+func triageColumns(vulnHeaders []string, vulnWidths map[string]float64) ([]string, map[string]float64) {
+	headers := append(append([]string(nil), vulnHeaders...), TriageHeaderValues...)
+	widths := make(map[string]float64)
+	for col, width := range vulnWidths {
+		widths[col] = width
+	}
+	for i, width := range TriageHeaderWidths {
+		col, _ := excelize.ColumnNumberToName(len(vulnHeaders) + i + 1)
+		widths[col] = width
+	}
+	return headers, widths
+}
+
+// withTriage appends the package URL and the triage cells to vulnerability rows,
+// filled in from the previous workbook's entry with the same key, if any.
+func withTriage(rows []sheetRow, previous map[string]triage.Entry) []sheetRow {
+	for i := range rows {
+		cells := make([]interface{}, len(TriageHeaderValues)-1)
+		if e, ok := previous[rows[i].key]; ok {
+			cells = triageCells(e)
+		}
+		rows[i].data = append(append(rows[i].data, rows[i].purl), cells...)
+	}
+	return rows
+}
+
+// triageCells renders the Owner, Decision, Due Date and Comment of an entry, leaving unset ones blank.
+func triageCells(e triage.Entry) []interface{} {
+	cells := make([]interface{}, 4)
+	for i, s := range []string{e.Owner, e.Decision, "", e.Comment} {
+		if s != "" {
+			cells[i] = sanitize(s)
+		}
+	}
+	if !e.DueDate.IsZero() {
+		cells[2] = e.DueDate
+	}
+	return cells
+}
+
+// triageStyles maps the triage input columns of a header to their styles: bordered cells without the
+// severity fill, so reviewers see what to fill in, and a date format for the due date.
+func triageStyles(st *sheetStyles, headers []string) map[int]int {
+	styles := make(map[int]int)
+	for i, h := range headers {
+		switch h {
+		case OwnerHeader, DecisionHeader, CommentHeader:
+			styles[i] = st.cell
+		case DueDateHeader:
+			styles[i] = st.date
+		}
+	}
+	return styles
+}
+
+// addTriageValidation restricts the Decision column to triage.Decisions and the Due Date column to dates,
+// for the given number of data rows. It must run before the sheet is streamed; the stream writer keeps it.
+func addTriageValidation(f *excelize.File, sheet string, headers []string, rows int) error {
+	lastRow := rows + 1
+	if lastRow < 2 {
+		lastRow = 2
+	}
+	for i, h := range headers {
+		col, _ := excelize.ColumnNumberToName(i + 1)
+		dv := excelize.NewDataValidation(true)
+		dv.Sqref = fmt.Sprintf("%s2:%s%d", col, col, lastRow)
+		switch h {
+		case DecisionHeader:
+			if err := dv.SetDropList(triage.Decisions); err != nil {
+				return err
+			}
+			dv.SetError(excelize.DataValidationErrorStyleStop, "Decision", "Choose one of "+strings.Join(triage.Decisions, ", "))
+		case DueDateHeader:
+			if err := dv.SetRange(1, lastExcelDate, excelize.DataValidationTypeDate, excelize.DataValidationOperatorBetween); err != nil {
+				return err
+			}
+			dv.SetError(excelize.DataValidationErrorStyleStop, "Due Date", "Enter a date")
+		default:
+			continue
+		}
+		if err := f.AddDataValidation(sheet, dv); err != nil {
+			return fmt.Errorf("failed to add %s validation: %w", h, err)
+		}
+	}
+	return nil
+}
+
+// ReadTriage reads the decisions recorded in the triage columns of a workbook exported with --triage.
+// Rows without a decision are skipped.
+func ReadTriage(path string) ([]triage.Entry, error) {
+	entries, err := readVulnEntries(path)
+	if err != nil {
+		return nil, err
+	}
+	var decided []triage.Entry
+	for _, e := range entries {
+		if e.Decision != "" {
+			decided = append(decided, e)
+		}
+	}
+	return decided, nil
+}
+
+// readVulnEntries reads every vulnerability listed by an exported workbook, with its triage columns when present.
+// Sheets without a Vulnerability ID column and those listing resolved vulnerabilities are skipped.
+func readVulnEntries(path string) ([]triage.Entry, error) {
+	f, err := excelize.OpenFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open workbook: %w", err)
+	}
+	defer f.Close()
+
+	var entries []triage.Entry
+	for _, sheet := range f.GetSheetList() {
+		if sheet == ResolvedReport || sheet == string(model.ChangeResolved) {
+			continue
+		}
+		sheetEntries, err := readTriageSheet(f, sheet)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", sheet, err)
+		}
+		entries = append(entries, sheetEntries...)
+	}
+	return entries, nil
+}
+
+// readTriageSheet reads the vulnerabilities of one sheet, or none when it does not list vulnerabilities.
+func readTriageSheet(f *excelize.File, sheet string) ([]triage.Entry, error) {
+	rows, err := f.Rows(sheet)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []triage.Entry
+	columns := make(map[string]int)
+	for rowNum := 1; rows.Next(); rowNum++ {
+		// Raw values keep due dates as serial numbers, whatever their display format
+		values, err := rows.Columns(excelize.Options{RawCellValue: true})
+		if err != nil {
+			return nil, err
+		}
+		cell := func(header string) string {
+			i, ok := columns[header]
+			if !ok || i >= len(values) {
+				return ""
+			}
+			return unsanitize(strings.TrimSpace(values[i]))
+		}
+
+		if rowNum == 1 {
+			for i, h := range values {
+				columns[h] = i
+			}
+			if _, ok := columns[LinkHeader]; !ok {
+				return nil, nil
+			}
+			continue
+		}
+
+		entry := triage.Entry{
+			Artifact:         cell("Artifact"),
+			Target:           cell("Target"),
+			VulnerabilityID:  cell(LinkHeader),
+			Package:          cell("Package Name"),
+			InstalledVersion: cell("Installed Version"),
+			Path:             cell("Path"),
+			PURL:             cell(PURLHeader),
+			Owner:            cell(OwnerHeader),
+			Decision:         cell(DecisionHeader),
+			Comment:          cell(CommentHeader),
+		}
+		if entry.VulnerabilityID == "" {
+			continue
+		}
+		if entry.Decision != "" && !triage.ValidDecision(entry.Decision) {
+			return nil, fmt.Errorf("row %d: unknown decision %q (want one of %s)", rowNum, entry.Decision, strings.Join(triage.Decisions, ", "))
+		}
+		if entry.DueDate, err = parseDueDate(cell(DueDateHeader)); err != nil {
+			return nil, fmt.Errorf("row %d: %w", rowNum, err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, rows.Error()
+}
+
+// parseDueDate reads a due date stored as an Excel serial date or typed as YYYY-MM-DD text.
+func parseDueDate(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if serial, err := strconv.ParseFloat(s, 64); err == nil {
+		return excelize.ExcelDateToTime(serial, false)
+	}
+	if t, err := time.Parse("2006-01-02", s); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid due date %q (want a date or YYYY-MM-DD)", s)
+}
+
+// unsanitize removes the quote sanitize puts before values that would read as formulas.
+func unsanitize(s string) string {
+	if len(s) > 1 && s[0] == '\'' && strings.ContainsRune("=+-@", rune(s[1])) {
+		return s[1:]
+	}
+	return s
+}
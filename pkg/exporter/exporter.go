@@ -0,0 +1,142 @@
+package exporter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"trivy-plugin-excel/pkg/model"
+)
+
+// Option names that exporters may declare as supported.
+const (
+	OptionBeautify          = "beautify"
+	OptionShowSecretContext = "show-secret-context"
+	OptionInventoryAppendix = "pdf-inventory"
+	OptionDetailsAppendix   = "pdf-details"
+	OptionCVSSSource        = "cvss-source"
+	OptionExcelLayout       = "excel-layout"
+	OptionTriage            = "triage"
+	OptionPrevious          = "previous"
+	OptionOrgName           = "org-name"
+	OptionOrgLogo           = "org-logo"
+)
+
+// Options carries the CLI settings shared by all exporters.
+// Each exporter only honours the options it lists in SupportedOptions.
+type Options struct {
+	Beautify bool
+	// ShowSecretContext disables masking of secret matches and code snippets
+	ShowSecretContext bool
+	// InventoryAppendix appends the full package inventory to the PDF
+	InventoryAppendix bool
+	// DetailsAppendix appends the advisory details of every vulnerability to the PDF, linked from the tables
+	DetailsAppendix bool
+	// CVSSSources is the vendor preference order (e.g. nvd, redhat, ghsa) for the CVSS columns
+	CVSSSources []string
+	// ExcelLayout splits the vulnerabilities into sheets: flat, per-target or per-class
+	ExcelLayout string
+	// Triage adds Owner, Decision, Due Date and Comment columns to the vulnerability sheets
+	Triage bool
+	// Previous is an earlier workbook whose triage columns are carried forward (implies Triage)
+	Previous string
+	// OrgName and OrgLogo (a PNG or JPEG file) brand the PDF cover page
+	OrgName string
+	OrgLogo string
+}
+
+// Exporter renders a Trivy report into a single output file.
+type Exporter interface {
+	// Name returns the short format name (e.g. "excel").
+	Name() string
+	// Extension returns the file extension including the leading dot (e.g. ".xlsx").
+	Extension() string
+	// SupportedOptions lists the option names this exporter honours.
+	SupportedOptions() []string
+	// Export writes the report to path.
+	Export(report *model.Report, path string, opts Options) error
+}
+
+// MultiFile is implemented by exporters that write further files next to the one at the export path.
+type MultiFile interface {
+	// Siblings returns the paths of the other files an export to path writes, in a stable order.
+	Siblings(path string) []string
+}
+
+var (
+	mu        sync.RWMutex
+	exporters = map[string]Exporter{}
+)
+
+// Register makes an exporter available by name and extension.
+// It panics if the name or extension is already registered, mirroring database/sql.Register.
+func Register(e Exporter) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	name := strings.ToLower(e.Name())
+	if _, dup := exporters[name]; dup {
+		panic(fmt.Sprintf("exporter: Register called twice for format %q", name))
+	}
+	for _, other := range exporters {
+		if strings.EqualFold(other.Extension(), e.Extension()) {
+			panic(fmt.Sprintf("exporter: extension %q already registered by %q", e.Extension(), other.Name()))
+		}
+	}
+	exporters[name] = e
+}
+
+// Get returns the exporter registered under the given format name.
+func Get(name string) (Exporter, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	e, ok := exporters[strings.ToLower(name)]
+	return e, ok
+}
+
+// ByExtension returns the exporter registered for the given file extension.
+func ByExtension(ext string) (Exporter, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	for _, e := range exporters {
+		if strings.EqualFold(e.Extension(), ext) {
+			return e, true
+		}
+	}
+	return nil, false
+}
+
+// All returns every registered exporter sorted by name.
+func All() []Exporter {
+	mu.RLock()
+	defer mu.RUnlock()
+	list := make([]Exporter, 0, len(exporters))
+	for _, e := range exporters {
+		list = append(list, e)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name() < list[j].Name()
+	})
+	return list
+}
+
+// Extensions returns the registered file extensions sorted alphabetically.
+func Extensions() []string {
+	var exts []string
+	for _, e := range All() {
+		exts = append(exts, e.Extension())
+	}
+	sort.Strings(exts)
+	return exts
+}
+
+// Supports reports whether the exporter declares the given option.
+func Supports(e Exporter, option string) bool {
+	for _, o := range e.SupportedOptions() {
+		if o == option {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,111 @@
+package exporter
+
+import (
+	"fmt"
+	"io"
+	"sync"
+
+	"trivy-plugin-excel/pkg/model"
+)
+
+// Result records the outcome of a single exporter run.
+type Result struct {
+	Format string
+	Path   string
+	// Siblings are the further files of a MultiFile exporter
+	Siblings []string
+	Err      error
+	Skipped  bool
+}
+
+// newResult returns the result of exporting to baseName with e, before it runs.
+func newResult(e Exporter, baseName string) Result {
+	r := Result{Format: e.Name(), Path: baseName + e.Extension()}
+	if m, ok := e.(MultiFile); ok {
+		r.Siblings = m.Siblings(r.Path)
+	}
+	return r
+}
+
+// Failed reports whether the export did not produce its file.
+func (r Result) Failed() bool {
+	return r.Err != nil || r.Skipped
+}
+
+// Run exports the report with every selected exporter, writing to baseName plus the exporter's extension.
+// With keepGoing the exporters run concurrently and all of them are attempted.
+// Without it they run one after another and the remaining ones are skipped after the first failure.
+// Results are returned in the same order as the selected exporters.
+func Run(report *model.Report, baseName string, selected []Exporter, opts Options, keepGoing bool) []Result {
+	results := make([]Result, len(selected))
+	for i, e := range selected {
+		results[i] = newResult(e, baseName)
+	}
+
+	if !keepGoing {
+		for i, e := range selected {
+			results[i].Err = e.Export(report, results[i].Path, opts)
+			if results[i].Err != nil {
+				for j := i + 1; j < len(results); j++ {
+					results[j].Skipped = true
+				}
+				break
+			}
+		}
+		return results
+	}
+
+	var wg sync.WaitGroup
+	for i, e := range selected {
+		wg.Add(1)
+		go func(i int, e Exporter) {
+			defer wg.Done()
+			results[i].Err = e.Export(report, results[i].Path, opts)
+		}(i, e)
+	}
+	wg.Wait()
+	return results
+}
+
+// Skip returns the results of exporting to baseName with every selected exporter without running them,
+// so the summary still accounts for outputs left out after an earlier failure.
+func Skip(baseName string, selected []Exporter) []Result {
+	results := make([]Result, len(selected))
+	for i, e := range selected {
+		results[i] = newResult(e, baseName)
+		results[i].Skipped = true
+	}
+	return results
+}
+
+// Failures returns the number of results that did not produce a file.
+func Failures(results []Result) int {
+	n := 0
+	for _, r := range results {
+		if r.Failed() {
+			n++
+		}
+	}
+	return n
+}
+
+// WriteSummary prints a per-format success/failure table, listing the sibling files under their format.
+func WriteSummary(w io.Writer, results []Result) {
+	fmt.Fprintln(w, "Export summary:")
+	for _, r := range results {
+		status := "OK"
+		switch {
+		case r.Err != nil:
+			status = "FAILED"
+			fmt.Fprintf(w, "  %-8s %-8s %s: %v\n", r.Format, status, r.Path, r.Err)
+		case r.Skipped:
+			status = "SKIPPED"
+			fmt.Fprintf(w, "  %-8s %-8s %s\n", r.Format, status, r.Path)
+		default:
+			fmt.Fprintf(w, "  %-8s %-8s %s\n", r.Format, status, r.Path)
+		}
+		for _, sibling := range r.Siblings {
+			fmt.Fprintf(w, "  %-8s %-8s %s\n", "", status, sibling)
+		}
+	}
+}
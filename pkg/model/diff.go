@@ -0,0 +1,106 @@
+package model
+
+import (
+	"strings"
+
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+// Change classifies a finding when comparing two scans.
+type Change string
+
+const (
+	ChangeIntroduced Change = "Introduced"
+	ChangeResolved   Change = "Resolved"
+	ChangePersisting Change = "Persisting"
+)
+
+// Changes lists every change category in display order.
+var Changes = []Change{ChangeIntroduced, ChangeResolved, ChangePersisting}
+
+// DiffFinding is a vulnerability together with how it changed between two scans.
+type DiffFinding struct {
+	Change        Change
+	Artifact      string
+	Target        string
+	Type          ftypes.TargetType
+	Class         types.ResultClass
+	Vulnerability types.DetectedVulnerability
+}
+
+// Diff is the comparison of an old and a new scan.
+type Diff struct {
+	OldArtifact string
+	NewArtifact string
+	Findings    []DiffFinding
+}
+
+// NewDiff classifies every vulnerability of both reports as introduced, resolved or persisting.
+// Findings are matched by (target, VulnerabilityID, PkgName, PkgPath).
+func NewDiff(oldReport, newReport *types.Report) *Diff {
+	d := &Diff{OldArtifact: oldReport.ArtifactName, NewArtifact: newReport.ArtifactName}
+
+	oldKeys := make(map[string]bool)
+	for _, result := range oldReport.Results {
+		for _, vuln := range result.Vulnerabilities {
+			oldKeys[diffKey(result, vuln)] = true
+		}
+	}
+
+	newKeys := make(map[string]bool)
+	for _, result := range newReport.Results {
+		for _, vuln := range result.Vulnerabilities {
+			key := diffKey(result, vuln)
+			newKeys[key] = true
+
+			change := ChangeIntroduced
+			if oldKeys[key] {
+				change = ChangePersisting
+			}
+			d.Findings = append(d.Findings, newDiffFinding(change, newReport.ArtifactName, result, vuln))
+		}
+	}
+
+	for _, result := range oldReport.Results {
+		for _, vuln := range result.Vulnerabilities {
+			if !newKeys[diffKey(result, vuln)] {
+				d.Findings = append(d.Findings, newDiffFinding(ChangeResolved, oldReport.ArtifactName, result, vuln))
+			}
+		}
+	}
+	return d
+}
+
+// ByChange returns the findings of a single change category.
+func (d *Diff) ByChange(c Change) []DiffFinding {
+	var findings []DiffFinding
+	for _, f := range d.Findings {
+		if f.Change == c {
+			findings = append(findings, f)
+		}
+	}
+	return findings
+}
+
+func newDiffFinding(c Change, artifactName string, result types.Result, vuln types.DetectedVulnerability) DiffFinding {
+	return DiffFinding{
+		Change:        c,
+		Artifact:      artifactName,
+		Target:        result.Target,
+		Type:          result.Type,
+		Class:         result.Class,
+		Vulnerability: vuln,
+	}
+}
+
+// diffKey identifies a finding across scans.
+// OS package targets embed the image name and OS version (e.g. "alpine:3.18 (alpine 3.18.4)"),
+// so they are matched by OS family instead; otherwise every image upgrade would look like a full replacement.
+func diffKey(result types.Result, vuln types.DetectedVulnerability) string {
+	target := result.Target
+	if result.Class == types.ClassOSPkg {
+		target = "os:" + string(result.Type)
+	}
+	return strings.Join([]string{target, vuln.VulnerabilityID, vuln.PkgName, vuln.PkgPath}, "|")
+}
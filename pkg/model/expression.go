@@ -0,0 +1,193 @@
+package model
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/aquasecurity/trivy/pkg/types"
+	"github.com/open-policy-agent/opa/v1/rego"
+)
+
+// labelPattern splits an optional "LABEL:" prefix off a filter expression (":=" is Rego, not a label).
+var labelPattern = regexp.MustCompile(`^([A-Za-z][\w-]*):([^=][\s\S]*)$`)
+
+// expression is a compiled filter expression.
+type expression struct {
+	label string
+	query rego.PreparedEvalQuery
+}
+
+// newExpression compiles "[LABEL:]QUERY"; the query itself is the label when none is given.
+// A vulnerability matches when the query succeeds, e.g.
+//
+//	node:startswith(input.PkgPath, "/usr/lib/node_modules"); input.Status == "will_not_fix"
+func newExpression(ctx context.Context, s string) (expression, error) {
+	label, query := s, s
+	if m := labelPattern.FindStringSubmatch(s); m != nil {
+		label, query = m[1], strings.TrimSpace(m[2])
+	}
+	prepared, err := rego.New(rego.Query(query)).PrepareForEval(ctx)
+	if err != nil {
+		return expression{}, fmt.Errorf("invalid filter expression %q: %w", s, err)
+	}
+	return expression{label: label, query: prepared}, nil
+}
+
+// matches evaluates the expression against a vulnerability input document.
+func (e expression) matches(ctx context.Context, input map[string]interface{}) (bool, error) {
+	rs, err := e.query.Eval(ctx, rego.EvalInput(input))
+	if err != nil {
+		return false, fmt.Errorf("failed to evaluate filter expression %q: %w", e.label, err)
+	}
+	// Top-level comparisons are reported with a false value rather than as an undefined query
+	for _, r := range rs {
+		matched := true
+		for _, expr := range r.Expressions {
+			if v, ok := expr.Value.(bool); ok && !v {
+				matched = false
+			}
+		}
+		if matched {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// vulnInput builds the document expressions see as input: the vulnerability's JSON fields
+// plus the Artifact, Target, Class and Type of the result it was found in.
+func vulnInput(artifactName string, result types.Result, vuln types.DetectedVulnerability) (map[string]interface{}, error) {
+	b, err := json.Marshal(vuln)
+	if err != nil {
+		return nil, err
+	}
+	input := make(map[string]interface{})
+	if err := json.Unmarshal(b, &input); err != nil {
+		return nil, err
+	}
+	input["Artifact"] = artifactName
+	input["Target"] = result.Target
+	input["Class"] = string(result.Class)
+	input["Type"] = string(result.Type)
+	return input, nil
+}
+
+// evaluate runs the filter expressions against every vulnerability, tagging or dropping the matches.
+// Dropped vulnerabilities are recorded in r.Suppressed with the labels of the expressions they matched.
+func (r *Report) evaluate(ctx context.Context, f Filter) error {
+	if len(f.Expressions) == 0 {
+		return nil
+	}
+	var exprs []expression
+	for _, s := range f.Expressions {
+		e, err := newExpression(ctx, s)
+		if err != nil {
+			return err
+		}
+		exprs = append(exprs, e)
+	}
+
+	// labels returns the labels of the expressions a vulnerability matches
+	labels := func(artifactName string, result types.Result, vuln types.DetectedVulnerability) ([]string, error) {
+		input, err := vulnInput(artifactName, result, vuln)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build filter input for %s: %w", vuln.VulnerabilityID, err)
+		}
+		var matched []string
+		for _, e := range exprs {
+			ok, err := e.matches(ctx, input)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				matched = append(matched, e.label)
+			}
+		}
+		return matched, nil
+	}
+
+	// drop records a vulnerability removed by the expressions as suppressed, once per artifact and target
+	dropped := make(map[string]bool)
+	drop := func(artifactName, target string, vuln types.DetectedVulnerability, matched []string) {
+		key := tagKey(artifactName, target, vuln)
+		if dropped[key] {
+			return
+		}
+		dropped[key] = true
+		r.Suppressed = append(r.Suppressed, Suppressed{
+			Artifact:  artifactName,
+			Target:    target,
+			Type:      types.FindingTypeVulnerability,
+			Status:    types.FindingStatusIgnored,
+			ID:        vuln.VulnerabilityID,
+			Package:   vuln.PkgName,
+			Severity:  vuln.Severity,
+			Statement: "Filtered by expression: " + strings.Join(matched, ", "),
+			Source:    "filter",
+		})
+	}
+
+	if r.tags == nil {
+		r.tags = make(map[string][]string)
+	}
+	for i := range r.Artifacts {
+		a := &r.Artifacts[i]
+		results := make(types.Results, len(a.Results))
+		for j, result := range a.Results {
+			var vulns []types.DetectedVulnerability
+			for _, vuln := range result.Vulnerabilities {
+				matched, err := labels(a.Name, result, vuln)
+				if err != nil {
+					return err
+				}
+				switch {
+				case len(matched) == 0:
+				case f.Action == ActionDrop:
+					drop(a.Name, result.Target, vuln, matched)
+					continue
+				default:
+					r.tags[tagKey(a.Name, result.Target, vuln)] = matched
+				}
+				vulns = append(vulns, vuln)
+			}
+			result.Vulnerabilities = vulns
+			results[j] = result
+		}
+		a.Results = results
+	}
+
+	// Introduced and persisting findings are also results of the new scan, recorded above; resolved ones are only here
+	if r.Diff != nil {
+		var findings []DiffFinding
+		for _, finding := range r.Diff.Findings {
+			result := types.Result{Target: finding.Target, Class: finding.Class, Type: finding.Type}
+			matched, err := labels(finding.Artifact, result, finding.Vulnerability)
+			if err != nil {
+				return err
+			}
+			if len(matched) > 0 {
+				if f.Action == ActionDrop {
+					drop(finding.Artifact, finding.Target, finding.Vulnerability, matched)
+					continue
+				}
+				r.tags[tagKey(finding.Artifact, finding.Target, finding.Vulnerability)] = matched
+			}
+			findings = append(findings, finding)
+		}
+		r.Diff.Findings = findings
+	}
+	return nil
+}
+
+// tagKey identifies a vulnerability of a target of an artifact, so merged artifacts are tagged apart.
+func tagKey(artifactName, target string, v types.DetectedVulnerability) string {
+	return artifactName + "|" + target + "|" + VulnKey(v)
+}
+
+// Tag returns the labels of the filter expressions a vulnerability of the named artifact matched, joined for display.
+func (r *Report) Tag(artifactName, target string, v types.DetectedVulnerability) string {
+	return strings.Join(r.tags[tagKey(artifactName, target, v)], ", ")
+}
@@ -0,0 +1,186 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+// Severities lists the Trivy severities from most to least severe.
+var Severities = []string{"CRITICAL", "HIGH", "MEDIUM", "LOW", "UNKNOWN"}
+
+// SeverityOrder is the position of a severity in Severities, starting at 1 for CRITICAL.
+// Severities Trivy does not define sort after UNKNOWN.
+func SeverityOrder(severity string) int {
+	for i, s := range Severities {
+		if s == severity {
+			return i + 1
+		}
+	}
+	return len(Severities) + 1
+}
+
+// FilterAction is what happens to the vulnerabilities a filter expression matches.
+type FilterAction string
+
+const (
+	// ActionTag keeps matching vulnerabilities and labels them in the Tag column
+	ActionTag FilterAction = "tag"
+	// ActionDrop removes matching vulnerabilities, listing them as suppressed
+	ActionDrop FilterAction = "drop"
+)
+
+// Filter selects the findings that are exported.
+// The zero value keeps everything.
+type Filter struct {
+	// Severities keeps only findings with one of these severities (all when empty)
+	Severities []string
+	// IgnoreUnfixed drops vulnerabilities without a fixed version
+	IgnoreUnfixed bool
+	// Expressions are Rego queries ("[LABEL:]QUERY") evaluated against each vulnerability and its result
+	Expressions []string
+	// Action is applied to the vulnerabilities matching an expression (ActionTag when empty)
+	Action FilterAction
+}
+
+// Validate checks that every severity is known, normalizing them to upper case.
+func (f *Filter) Validate() error {
+	for i, s := range f.Severities {
+		s = strings.ToUpper(strings.TrimSpace(s))
+		if !containsString(Severities, s) {
+			return fmt.Errorf("unknown severity %q (expected one of %s)", s, strings.Join(Severities, ", "))
+		}
+		f.Severities[i] = s
+	}
+
+	switch f.Action {
+	case "":
+		f.Action = ActionTag
+	case ActionTag, ActionDrop:
+	default:
+		return fmt.Errorf("unknown filter action %q (expected %s or %s)", f.Action, ActionTag, ActionDrop)
+	}
+	for _, e := range f.Expressions {
+		if _, err := newExpression(context.Background(), e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Active reports whether the filter drops or tags anything.
+func (f Filter) Active() bool {
+	return len(f.Severities) > 0 || f.IgnoreUnfixed || len(f.Expressions) > 0
+}
+
+// Describe lists the active filters for display, e.g. "Severity: CRITICAL, HIGH".
+func (f Filter) Describe() []string {
+	var active []string
+	if len(f.Severities) > 0 {
+		active = append(active, "Severity: "+strings.Join(f.Severities, ", "))
+	}
+	if f.IgnoreUnfixed {
+		active = append(active, "Unfixed vulnerabilities: ignored")
+	}
+	for _, e := range f.Expressions {
+		active = append(active, fmt.Sprintf("Expression (%s): %s", f.Action, e))
+	}
+	return active
+}
+
+// Apply removes the findings the filter rejects from every artifact and from the diff,
+// and records the filter so exporters can show it. Results are copied, never modified in place.
+// Expressions run first, so the vulnerabilities they drop are listed as suppressed whatever their severity.
+func (r *Report) Apply(ctx context.Context, f Filter) error {
+	if !f.Active() {
+		return nil
+	}
+	r.Filter = f
+
+	if err := r.evaluate(ctx, f); err != nil {
+		return err
+	}
+
+	if r.hidden == nil {
+		r.hidden = make(map[string]bool)
+	}
+	for i := range r.Artifacts {
+		a := &r.Artifacts[i]
+		results := make(types.Results, len(a.Results))
+		for j, result := range a.Results {
+			for _, vuln := range result.Vulnerabilities {
+				if !f.keepVulnerability(vuln) {
+					r.hidden[VulnKey(vuln)] = true
+				}
+			}
+			results[j] = f.filterResult(result)
+		}
+		a.Results = results
+	}
+
+	if r.Diff != nil {
+		var findings []DiffFinding
+		for _, finding := range r.Diff.Findings {
+			if f.keepVulnerability(finding.Vulnerability) {
+				findings = append(findings, finding)
+			}
+		}
+		r.Diff.Findings = findings
+	}
+	return nil
+}
+
+// Hidden reports whether a vulnerability with the given key (VulnKey) was scanned but left out by the
+// --severity or --ignore-unfixed filter. Expression drops are listed in Suppressed instead.
+func (r *Report) Hidden(key string) bool {
+	return r.hidden[key]
+}
+
+func (f Filter) filterResult(result types.Result) types.Result {
+	var vulns []types.DetectedVulnerability
+	for _, vuln := range result.Vulnerabilities {
+		if f.keepVulnerability(vuln) {
+			vulns = append(vulns, vuln)
+		}
+	}
+	result.Vulnerabilities = vulns
+
+	var misconfs []types.DetectedMisconfiguration
+	for _, misconf := range result.Misconfigurations {
+		if f.keepSeverity(misconf.Severity) {
+			misconfs = append(misconfs, misconf)
+		}
+	}
+	result.Misconfigurations = misconfs
+
+	var secrets []types.DetectedSecret
+	for _, secret := range result.Secrets {
+		if f.keepSeverity(secret.Severity) {
+			secrets = append(secrets, secret)
+		}
+	}
+	result.Secrets = secrets
+
+	var licenses []types.DetectedLicense
+	for _, license := range result.Licenses {
+		if f.keepSeverity(license.Severity) {
+			licenses = append(licenses, license)
+		}
+	}
+	result.Licenses = licenses
+
+	return result
+}
+
+func (f Filter) keepVulnerability(vuln types.DetectedVulnerability) bool {
+	if f.IgnoreUnfixed && vuln.FixedVersion == "" {
+		return false
+	}
+	return f.keepSeverity(vuln.Severity)
+}
+
+func (f Filter) keepSeverity(severity string) bool {
+	return len(f.Severities) == 0 || containsString(f.Severities, severity)
+}
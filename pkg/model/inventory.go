@@ -0,0 +1,81 @@
+package model
+
+import (
+	"fmt"
+
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+// InventoryItem is a package from result.Packages with the number of vulnerabilities found in it.
+type InventoryItem struct {
+	Artifact        string
+	Target          string
+	Class           types.ResultClass
+	Package         ftypes.Package
+	Vulnerabilities int
+	// MaxSeverity is the highest severity among the package's vulnerabilities, empty when it has none
+	MaxSeverity string
+}
+
+// Inventory lists every package of the report (populated by trivy --list-all-pkgs), grouped by artifact and target.
+func (r *Report) Inventory() []InventoryItem {
+	var items []InventoryItem
+	for _, artifact := range r.Artifacts {
+		for _, result := range artifact.Results {
+			for _, pkg := range result.Packages {
+				item := InventoryItem{
+					Artifact: artifact.Name,
+					Target:   result.Target,
+					Class:    result.Class,
+					Package:  pkg,
+				}
+				for _, vuln := range result.Vulnerabilities {
+					if !vulnerabilityOf(pkg, vuln) {
+						continue
+					}
+					item.Vulnerabilities++
+					if item.MaxSeverity == "" || SeverityOrder(vuln.Severity) < SeverityOrder(item.MaxSeverity) {
+						item.MaxSeverity = vuln.Severity
+					}
+				}
+				items = append(items, item)
+			}
+		}
+	}
+	return items
+}
+
+// HasPackages reports whether any result carries a package inventory.
+func (r *Report) HasPackages() bool {
+	for _, result := range r.Results() {
+		if len(result.Packages) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// PackageVersion renders a package version the way Trivy reports InstalledVersion ([epoch:]version[-release]).
+func PackageVersion(pkg ftypes.Package) string {
+	version := pkg.Version
+	if pkg.Release != "" {
+		version += "-" + pkg.Release
+	}
+	if pkg.Epoch > 0 {
+		version = fmt.Sprintf("%d:%s", pkg.Epoch, version)
+	}
+	return version
+}
+
+// vulnerabilityOf reports whether a vulnerability was detected in the given package,
+// matching on the package UID, then the package ID, then name and version.
+func vulnerabilityOf(pkg ftypes.Package, vuln types.DetectedVulnerability) bool {
+	if pkg.Identifier.UID != "" && vuln.PkgIdentifier.UID != "" {
+		return pkg.Identifier.UID == vuln.PkgIdentifier.UID
+	}
+	if pkg.ID != "" && vuln.PkgID != "" {
+		return pkg.ID == vuln.PkgID
+	}
+	return pkg.Name == vuln.PkgName && PackageVersion(pkg) == vuln.InstalledVersion
+}
@@ -0,0 +1,92 @@
+package model
+
+import (
+	"sort"
+
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+)
+
+// LicenseCategories lists the Trivy license categories from most to least restrictive.
+var LicenseCategories = []ftypes.LicenseCategory{
+	ftypes.CategoryForbidden,
+	ftypes.CategoryRestricted,
+	ftypes.CategoryReciprocal,
+	ftypes.CategoryNotice,
+	ftypes.CategoryPermissive,
+	ftypes.CategoryUnencumbered,
+	ftypes.CategoryUnknown,
+}
+
+// LicenseCount summarizes the license findings of one category.
+type LicenseCount struct {
+	Category ftypes.LicenseCategory
+	Findings int
+	// Licenses holds the distinct license names, sorted
+	Licenses []string
+}
+
+// LicenseSummary counts license findings per category, in LicenseCategories order.
+// Categories Trivy does not know about are counted as unknown.
+func (r *Report) LicenseSummary() []LicenseCount {
+	findings := make(map[ftypes.LicenseCategory]int)
+	names := make(map[ftypes.LicenseCategory]map[string]bool)
+	for _, result := range r.Results() {
+		for _, license := range result.Licenses {
+			category := license.Category
+			if !knownLicenseCategory(category) {
+				category = ftypes.CategoryUnknown
+			}
+			findings[category]++
+			if names[category] == nil {
+				names[category] = make(map[string]bool)
+			}
+			names[category][license.Name] = true
+		}
+	}
+
+	summary := make([]LicenseCount, 0, len(LicenseCategories))
+	for _, category := range LicenseCategories {
+		count := LicenseCount{Category: category, Findings: findings[category]}
+		for name := range names[category] {
+			count.Licenses = append(count.Licenses, name)
+		}
+		sort.Strings(count.Licenses)
+		summary = append(summary, count)
+	}
+	return summary
+}
+
+// HasLicenses reports whether any result carries license findings.
+func (r *Report) HasLicenses() bool {
+	for _, result := range r.Results() {
+		if len(result.Licenses) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// LicenseSeverity returns the severity Trivy assigns to a license category by default.
+func LicenseSeverity(c ftypes.LicenseCategory) string {
+	switch c {
+	case ftypes.CategoryForbidden:
+		return "CRITICAL"
+	case ftypes.CategoryRestricted:
+		return "HIGH"
+	case ftypes.CategoryReciprocal:
+		return "MEDIUM"
+	case ftypes.CategoryNotice, ftypes.CategoryPermissive, ftypes.CategoryUnencumbered:
+		return "LOW"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+func knownLicenseCategory(c ftypes.LicenseCategory) bool {
+	for _, known := range LicenseCategories {
+		if c == known {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,133 @@
+package model
+
+import (
+	"strings"
+	"time"
+
+	"github.com/aquasecurity/trivy/pkg/fanal/artifact"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+// Report is the model rendered by the exporters.
+// It holds one artifact for a plain Trivy report, or several when reports are merged.
+type Report struct {
+	Artifacts []Artifact
+
+	// Diff is set when the report compares two scans instead of listing one
+	Diff *Diff
+
+	// Filter records the filter applied to the findings, if any
+	Filter Filter
+
+	// Suppressed lists the findings accepted by an ignore file or VEX statement
+	Suppressed []Suppressed
+
+	// occurrences maps a deduplicated finding key to every artifact it was found in
+	occurrences map[string][]string
+
+	// tags maps an artifact, target and finding key to the labels of the filter expressions it matched
+	tags map[string][]string
+
+	// hidden holds the keys (VulnKey) of the scanned vulnerabilities the filter removed
+	hidden map[string]bool
+}
+
+// Artifact is a single scanned artifact together with its results.
+type Artifact struct {
+	Name          string
+	Type          artifact.Type
+	Metadata      types.Metadata
+	SchemaVersion int
+	CreatedAt     time.Time
+	Results       types.Results
+}
+
+// New builds a report model from one or more Trivy reports, keeping one artifact per report.
+func New(reports ...*types.Report) *Report {
+	r := &Report{}
+	for _, tr := range reports {
+		r.Artifacts = append(r.Artifacts, Artifact{
+			Name:          tr.ArtifactName,
+			Type:          tr.ArtifactType,
+			Metadata:      tr.Metadata,
+			SchemaVersion: tr.SchemaVersion,
+			CreatedAt:     tr.CreatedAt,
+			Results:       tr.Results,
+		})
+		// Findings already suppressed by trivy --show-suppressed are kept for the record
+		for _, result := range tr.Results {
+			for _, m := range result.ModifiedFindings {
+				r.Suppressed = append(r.Suppressed, suppressedFinding(tr.ArtifactName, result.Target, m))
+			}
+		}
+	}
+	return r
+}
+
+// Merged reports whether the model combines more than one artifact.
+func (r *Report) Merged() bool {
+	return len(r.Artifacts) > 1
+}
+
+// VulnKey identifies the same vulnerable package across artifacts.
+func VulnKey(v types.DetectedVulnerability) string {
+	return strings.Join([]string{v.VulnerabilityID, v.PkgName, v.InstalledVersion, v.PkgPath}, "|")
+}
+
+// Dedupe keeps only the first occurrence of each vulnerability (by VulnKey) across artifacts.
+// The artifacts a dropped duplicate was found in are still reported by ArtifactNames.
+func (r *Report) Dedupe() {
+	r.occurrences = make(map[string][]string)
+	for i := range r.Artifacts {
+		a := &r.Artifacts[i]
+		results := make(types.Results, len(a.Results))
+		for j, result := range a.Results {
+			var kept []types.DetectedVulnerability
+			for _, vuln := range result.Vulnerabilities {
+				key := VulnKey(vuln)
+				seen, dup := r.occurrences[key]
+				if !containsString(seen, a.Name) {
+					r.occurrences[key] = append(seen, a.Name)
+				}
+				if !dup {
+					kept = append(kept, vuln)
+				}
+			}
+			result.Vulnerabilities = kept
+			results[j] = result
+		}
+		a.Results = results
+	}
+}
+
+// ArtifactNames returns the artifacts a vulnerability was found in.
+// Without deduplication this is just the artifact being rendered.
+func (r *Report) ArtifactNames(a *Artifact, v types.DetectedVulnerability) []string {
+	if names, ok := r.occurrences[VulnKey(v)]; ok {
+		return names
+	}
+	return []string{a.Name}
+}
+
+// ArtifactLabel returns ArtifactNames joined for display in a single cell.
+func (r *Report) ArtifactLabel(a *Artifact, v types.DetectedVulnerability) string {
+	return strings.Join(r.ArtifactNames(a, v), ", ")
+}
+
+// Results returns the results of every artifact in order.
+func (r *Report) Results() types.Results {
+	var results types.Results
+	for _, a := range r.Artifacts {
+		results = append(results, a.Results...)
+	}
+	return results
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,64 @@
+package model
+
+import (
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+// TargetSummary counts the vulnerabilities of one scan target per severity.
+type TargetSummary struct {
+	Artifact string
+	Target   string
+	Class    types.ResultClass
+	// Counts is keyed by the entries of Severities; unknown severities are counted as UNKNOWN
+	Counts map[string]int
+	Total  int
+}
+
+// TargetSummaries counts the vulnerabilities of every target that has any, in report order.
+func (r *Report) TargetSummaries() []TargetSummary {
+	var summaries []TargetSummary
+	for _, a := range r.Artifacts {
+		for _, result := range a.Results {
+			if len(result.Vulnerabilities) == 0 {
+				continue
+			}
+			s := TargetSummary{Artifact: a.Name, Target: result.Target, Class: result.Class, Counts: make(map[string]int)}
+			for _, vuln := range result.Vulnerabilities {
+				s.Counts[normalizeSeverity(vuln.Severity)]++
+				s.Total++
+			}
+			summaries = append(summaries, s)
+		}
+	}
+	return summaries
+}
+
+// SeverityTotals counts every vulnerability of the report per severity (keyed by Severities entries).
+func (r *Report) SeverityTotals() map[string]int {
+	totals := make(map[string]int)
+	for _, result := range r.Results() {
+		for _, vuln := range result.Vulnerabilities {
+			totals[normalizeSeverity(vuln.Severity)]++
+		}
+	}
+	return totals
+}
+
+// PackageCounts counts the vulnerabilities of each package name across the report.
+func (r *Report) PackageCounts() map[string]int {
+	counts := make(map[string]int)
+	for _, result := range r.Results() {
+		for _, vuln := range result.Vulnerabilities {
+			counts[vuln.PkgName]++
+		}
+	}
+	return counts
+}
+
+// normalizeSeverity maps severities Trivy does not define (or leaves empty) to UNKNOWN.
+func normalizeSeverity(severity string) string {
+	if containsString(Severities, severity) {
+		return severity
+	}
+	return "UNKNOWN"
+}
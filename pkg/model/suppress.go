@@ -0,0 +1,239 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/result"
+	"github.com/aquasecurity/trivy/pkg/types"
+	"github.com/aquasecurity/trivy/pkg/vex"
+)
+
+// Suppression names the documents that accept findings as not applicable.
+// The zero value suppresses nothing.
+type Suppression struct {
+	// IgnoreFile is a .trivyignore or .trivyignore.yaml file; a missing file is not an error
+	IgnoreFile string
+	// VEXSources are OpenVEX, CycloneDX or CSAF documents, or "repo"/"oci" as accepted by trivy --vex
+	VEXSources []string
+}
+
+// Suppressed is a finding removed from the report by an ignore file or VEX statement.
+type Suppressed struct {
+	Artifact  string
+	Target    string
+	Type      types.FindingType
+	Status    types.FindingStatus
+	ID        string
+	Package   string
+	Severity  string
+	Statement string
+	Source    string
+	// ExpiresAt is when an ignore entry stops applying (zero when it never expires or is unknown)
+	ExpiresAt time.Time
+}
+
+// Expires renders the expiry date of an ignore entry, or "" when it has none.
+func (s Suppressed) Expires() string {
+	if s.ExpiresAt.IsZero() {
+		return ""
+	}
+	return s.ExpiresAt.Format("2006-01-02")
+}
+
+// HasSuppressed reports whether any finding was suppressed.
+func (r *Report) HasSuppressed() bool {
+	return len(r.Suppressed) > 0
+}
+
+// Suppress moves the findings matched by the ignore file or a VEX statement from the results to r.Suppressed.
+// Expired ignore entries no longer apply. Results are copied, never modified in place.
+func (r *Report) Suppress(ctx context.Context, s Suppression) error {
+	conf, err := result.ParseIgnoreFile(ctx, s.IgnoreFile)
+	if err != nil {
+		return fmt.Errorf("failed to read ignore file: %w", err)
+	}
+	var sources []vex.Source
+	for _, src := range s.VEXSources {
+		sources = append(sources, vex.NewSource(src))
+	}
+
+	suppressedVulns := make(map[string]bool)
+	for i := range r.Artifacts {
+		a := &r.Artifacts[i]
+		results := make(types.Results, len(a.Results))
+		for j, res := range a.Results {
+			results[j] = r.ignoreResult(a.Name, res, conf)
+		}
+		a.Results = results
+
+		if len(sources) > 0 {
+			if err := r.applyVEX(ctx, a, sources); err != nil {
+				return err
+			}
+		}
+	}
+	for _, sup := range r.Suppressed {
+		if sup.Type == types.FindingTypeVulnerability {
+			suppressedVulns[sup.Target+"|"+sup.ID+"|"+sup.Package] = true
+		}
+	}
+
+	// A suppressed vulnerability is neither introduced nor persisting
+	if r.Diff != nil {
+		var findings []DiffFinding
+		for _, finding := range r.Diff.Findings {
+			v := finding.Vulnerability
+			if finding.Change != ChangeResolved {
+				if suppressedVulns[finding.Target+"|"+v.VulnerabilityID+"|"+v.PkgName] {
+					continue
+				}
+				if conf.MatchVulnerability(v.VulnerabilityID, finding.Target, v.PkgPath, v.PkgIdentifier.PURL) != nil {
+					continue
+				}
+			}
+			findings = append(findings, finding)
+		}
+		r.Diff.Findings = findings
+	}
+	return nil
+}
+
+// ignoreResult drops the findings of a result that match the ignore file, recording them as suppressed.
+func (r *Report) ignoreResult(artifactName string, res types.Result, conf result.IgnoreConfig) types.Result {
+	suppress := func(f *result.IgnoreFinding, typ types.FindingType, id, pkg, severity string) {
+		r.Suppressed = append(r.Suppressed, Suppressed{
+			Artifact:  artifactName,
+			Target:    res.Target,
+			Type:      typ,
+			Status:    types.FindingStatusIgnored,
+			ID:        id,
+			Package:   pkg,
+			Severity:  severity,
+			Statement: f.Statement,
+			Source:    conf.FilePath,
+			ExpiresAt: f.ExpiredAt,
+		})
+	}
+
+	var vulns []types.DetectedVulnerability
+	for _, vuln := range res.Vulnerabilities {
+		if f := conf.MatchVulnerability(vuln.VulnerabilityID, res.Target, vuln.PkgPath, vuln.PkgIdentifier.PURL); f != nil {
+			suppress(f, types.FindingTypeVulnerability, vuln.VulnerabilityID, vuln.PkgName, vuln.Severity)
+			continue
+		}
+		vulns = append(vulns, vuln)
+	}
+	res.Vulnerabilities = vulns
+
+	var misconfs []types.DetectedMisconfiguration
+	for _, misconf := range res.Misconfigurations {
+		if f := conf.MatchMisconfiguration(misconf.ID, misconf.AVDID, res.Target); f != nil {
+			suppress(f, types.FindingTypeMisconfiguration, misconf.ID, "", misconf.Severity)
+			continue
+		}
+		misconfs = append(misconfs, misconf)
+	}
+	res.Misconfigurations = misconfs
+
+	var secrets []types.DetectedSecret
+	for _, secret := range res.Secrets {
+		if f := conf.MatchSecret(secret.RuleID, res.Target); f != nil {
+			suppress(f, types.FindingTypeSecret, secret.RuleID, "", secret.Severity)
+			continue
+		}
+		secrets = append(secrets, secret)
+	}
+	res.Secrets = secrets
+
+	var licenses []types.DetectedLicense
+	for _, license := range res.Licenses {
+		if f := conf.MatchLicense(license.Name, license.FilePath); f != nil {
+			suppress(f, types.FindingTypeLicense, license.Name, license.PkgName, license.Severity)
+			continue
+		}
+		licenses = append(licenses, license)
+	}
+	res.Licenses = licenses
+
+	return res
+}
+
+// applyVEX drops the vulnerabilities of an artifact that the VEX documents state are not affected or fixed.
+func (r *Report) applyVEX(ctx context.Context, a *Artifact, sources []vex.Source) error {
+	report := &types.Report{
+		SchemaVersion: a.SchemaVersion,
+		CreatedAt:     a.CreatedAt,
+		ArtifactName:  a.Name,
+		ArtifactType:  a.Type,
+		Metadata:      a.Metadata,
+		Results:       make(types.Results, len(a.Results)),
+	}
+	// Trivy records what VEX filtered in ModifiedFindings; start from a clean slate to pick out only those
+	for i, res := range a.Results {
+		res.Vulnerabilities = append([]types.DetectedVulnerability(nil), res.Vulnerabilities...)
+		res.Packages = vulnerablePackages(res)
+		res.ModifiedFindings = nil
+		report.Results[i] = res
+	}
+	if err := vex.Filter(ctx, report, vex.Options{Sources: sources}); err != nil {
+		return fmt.Errorf("failed to apply VEX: %w", err)
+	}
+
+	for i, res := range report.Results {
+		for _, m := range res.ModifiedFindings {
+			r.Suppressed = append(r.Suppressed, suppressedFinding(a.Name, res.Target, m))
+		}
+		a.Results[i].Vulnerabilities = res.Vulnerabilities
+	}
+	return nil
+}
+
+// vulnerablePackages returns the packages of a result, adding one for every vulnerable package missing from them.
+// VEX statements are matched against package components, which reports without --list-all-pkgs lack.
+func vulnerablePackages(res types.Result) []ftypes.Package {
+	pkgs := append([]ftypes.Package(nil), res.Packages...)
+	known := make(map[string]bool)
+	for _, pkg := range pkgs {
+		known[pkg.Identifier.UID] = true
+	}
+	for _, vuln := range res.Vulnerabilities {
+		if vuln.PkgIdentifier.UID == "" || known[vuln.PkgIdentifier.UID] {
+			continue
+		}
+		known[vuln.PkgIdentifier.UID] = true
+		pkgs = append(pkgs, ftypes.Package{
+			ID:         vuln.PkgID,
+			Name:       vuln.PkgName,
+			Version:    vuln.InstalledVersion,
+			FilePath:   vuln.PkgPath,
+			Identifier: vuln.PkgIdentifier,
+		})
+	}
+	return pkgs
+}
+
+// suppressedFinding converts a finding Trivy itself marked as modified (by VEX or trivy --show-suppressed).
+func suppressedFinding(artifactName, target string, m types.ModifiedFinding) Suppressed {
+	s := Suppressed{
+		Artifact:  artifactName,
+		Target:    target,
+		Type:      m.Type,
+		Status:    m.Status,
+		Statement: m.Statement,
+		Source:    m.Source,
+	}
+	switch f := m.Finding.(type) {
+	case types.DetectedVulnerability:
+		s.ID, s.Package, s.Severity = f.VulnerabilityID, f.PkgName, f.Severity
+	case types.DetectedMisconfiguration:
+		s.ID, s.Severity = f.ID, f.Severity
+	case types.DetectedSecret:
+		s.ID, s.Severity = f.RuleID, f.Severity
+	case types.DetectedLicense:
+		s.ID, s.Package, s.Severity = f.Name, f.PkgName, f.Severity
+	}
+	return s
+}
@@ -0,0 +1,170 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"sort"
+
+	mimage "github.com/johnfercher/maroto/v2/pkg/components/image"
+	"github.com/johnfercher/maroto/v2/pkg/components/row"
+	"github.com/johnfercher/maroto/v2/pkg/components/text"
+	"github.com/johnfercher/maroto/v2/pkg/consts/align"
+	"github.com/johnfercher/maroto/v2/pkg/consts/extension"
+	"github.com/johnfercher/maroto/v2/pkg/consts/fontfamily"
+	"github.com/johnfercher/maroto/v2/pkg/consts/fontstyle"
+	"github.com/johnfercher/maroto/v2/pkg/core"
+	"github.com/johnfercher/maroto/v2/pkg/props"
+	"trivy-plugin-excel/pkg/model"
+)
+
+const (
+	// ChartTargetCount is how many targets, most vulnerable first, the per-target chart shows
+	ChartTargetCount = 10
+
+	// contentWidth is the printable width in mm: landscape A4 less the 10 mm side margins
+	contentWidth = 277.0
+	// barThickness is the height of a chart bar in mm
+	barThickness = 4.0
+	// barPixels is the width in pixels of every bar image
+	barPixels = 1200
+)
+
+var (
+	// LAYOUT: Severity(2), Bar(8), Count(2) -> Total 12
+	severityChartColWidths = []int{2, 8, 2}
+	// LAYOUT: Target(4), Bar(7), Total(1) -> Total 12
+	targetChartColWidths = []int{4, 7, 1}
+)
+
+// barSegment is a colored part of a bar, as a fraction of the full bar width.
+type barSegment struct {
+	fraction float64
+	color    *props.Color
+}
+
+// barImage draws a horizontal bar as a PNG spanning a column of size grid units: the segments
+// left to right, transparent after them. Its aspect ratio makes it fill the column at barThickness.
+func barImage(size int, segments []barSegment) ([]byte, error) {
+	height := int(float64(barPixels) * barThickness / gridWidth(size))
+	if height < 1 {
+		height = 1
+	}
+	img := image.NewRGBA(image.Rect(0, 0, barPixels, height))
+	x := 0
+	for _, s := range segments {
+		width := int(s.fraction*barPixels + 0.5)
+		c := color.RGBA{R: uint8(s.color.Red), G: uint8(s.color.Green), B: uint8(s.color.Blue), A: 255}
+		for px := x; px < x+width && px < barPixels; px++ {
+			for py := 0; py < height; py++ {
+				img.SetRGBA(px, py, c)
+			}
+		}
+		x += width
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("failed to draw chart: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// chartRows renders the executive summary charts: vulnerabilities per severity, then a bar per target
+// stacked by severity, both in the severity palette of the tables. Nothing is rendered without vulnerabilities.
+func chartRows(report *model.Report) ([]core.Row, error) {
+	totals := report.SeverityTotals()
+	maxCount := 0
+	for _, severity := range model.Severities {
+		if totals[severity] > maxCount {
+			maxCount = totals[severity]
+		}
+	}
+	if maxCount == 0 {
+		return nil, nil
+	}
+
+	labelProp := bodyProp
+	labelProp.Style = fontstyle.Bold
+	countProp := bodyProp
+	countProp.Align = align.Left
+	titleProp := props.Text{Top: 3, Style: fontstyle.Bold, Size: 9, Family: fontfamily.Arial, Color: ColorDarkGray, Align: align.Left}
+	barProp := props.Rect{Top: 1.5, Percent: 100}
+
+	// --- Vulnerabilities by severity ---
+	rows := []core.Row{text.NewRow(10, "Vulnerabilities by Severity", titleProp)}
+	for _, severity := range model.Severities {
+		count := totals[severity]
+		bar, err := barImage(severityChartColWidths[1], []barSegment{{float64(count) / float64(maxCount), getSeverityColor(severity)}})
+		if err != nil {
+			return nil, err
+		}
+		sevProp := labelProp
+		sevProp.Color = getSeverityColor(severity)
+		countProp := countProp
+		countProp.Left = 2
+		rows = append(rows, row.New(7).Add(
+			text.NewCol(severityChartColWidths[0], severity, sevProp),
+			mimage.NewFromBytesCol(severityChartColWidths[1], bar, extension.Png, barProp),
+			text.NewCol(severityChartColWidths[2], fmt.Sprint(count), countProp),
+		))
+	}
+
+	// --- Vulnerabilities by target, stacked by severity ---
+	summaries := report.TargetSummaries()
+	sort.SliceStable(summaries, func(i, j int) bool { return summaries[i].Total > summaries[j].Total })
+	more := 0
+	if len(summaries) > ChartTargetCount {
+		more = len(summaries) - ChartTargetCount
+		summaries = summaries[:ChartTargetCount]
+	}
+	legend := row.New(10).Add(text.NewCol(targetChartColWidths[0], "Vulnerabilities by Target", titleProp))
+	for _, severity := range model.Severities {
+		legendProp := labelProp
+		legendProp.Top = 3.5
+		legendProp.Size = 7
+		legendProp.Color = getSeverityColor(severity)
+		legend.Add(text.NewCol(1, severity, legendProp))
+	}
+	legend.Add(text.NewCol(12-targetChartColWidths[0]-len(model.Severities), "", props.Text{}))
+	rows = append(rows, legend)
+
+	maxTotal := summaries[0].Total
+	for _, s := range summaries {
+		var segments []barSegment
+		for _, severity := range model.Severities {
+			segments = append(segments, barSegment{float64(s.Counts[severity]) / float64(maxTotal), getSeverityColor(severity)})
+		}
+		bar, err := barImage(targetChartColWidths[1], segments)
+		if err != nil {
+			return nil, err
+		}
+		target := s.Target
+		if report.Merged() {
+			target = s.Artifact + ": " + target
+		}
+		rows = append(rows, row.New(7).Add(
+			text.NewCol(targetChartColWidths[0], truncate(target, 60), bodyProp),
+			mimage.NewFromBytesCol(targetChartColWidths[1], bar, extension.Png, barProp),
+			text.NewCol(targetChartColWidths[2], fmt.Sprint(s.Total), labelProp),
+		))
+	}
+	if more > 0 {
+		noteProp := bodyProp
+		noteProp.Style = fontstyle.Italic
+		noteProp.Color = ColorGrayText
+		rows = append(rows, text.NewRow(6, fmt.Sprintf("... and %d more target(s)", more), noteProp))
+	}
+	return rows, nil
+}
+
+// truncate shortens s to at most n runes, marking the cut with an ellipsis.
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n-3]) + "..."
+}
@@ -0,0 +1,162 @@
+package pdf
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/johnfercher/maroto/v2/pkg/components/image"
+	"github.com/johnfercher/maroto/v2/pkg/components/row"
+	"github.com/johnfercher/maroto/v2/pkg/components/text"
+	"github.com/johnfercher/maroto/v2/pkg/consts/align"
+	"github.com/johnfercher/maroto/v2/pkg/consts/border"
+	"github.com/johnfercher/maroto/v2/pkg/consts/fontfamily"
+	"github.com/johnfercher/maroto/v2/pkg/consts/fontstyle"
+	"github.com/johnfercher/maroto/v2/pkg/core"
+	"github.com/johnfercher/maroto/v2/pkg/props"
+	"trivy-plugin-excel/pkg/exporter"
+	"trivy-plugin-excel/pkg/model"
+	"trivy-plugin-excel/pkg/utils"
+)
+
+// LAYOUT: Label(3), Value(9) -> Total 12
+var coverColWidths = []int{3, 9}
+
+// checkLogo rejects a logo the PDF cannot embed, before any page is rendered.
+func checkLogo(path string) error {
+	if path == "" {
+		return nil
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".png", ".jpg", ".jpeg":
+	default:
+		return fmt.Errorf("unsupported logo format %s (want PNG or JPEG)", filepath.Ext(path))
+	}
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("failed to read logo: %w", err)
+	}
+	return nil
+}
+
+// coverRows builds the cover page: the organization, then what was scanned and when, per artifact,
+// so the report stands on its own for audits. generatedAt is when the PDF itself was rendered.
+func coverRows(report *model.Report, opts exporter.Options, generatedAt time.Time) []core.Row {
+	var rows []core.Row
+
+	// --- Organization (optional) ---
+	if opts.OrgLogo != "" || opts.OrgName != "" {
+		orgRow := row.New(20)
+		nameWidth := 12
+		if opts.OrgLogo != "" {
+			orgRow.Add(image.NewFromFileCol(3, opts.OrgLogo, props.Rect{Center: true, Percent: 90}))
+			nameWidth = 9
+		}
+		orgRow.Add(text.NewCol(nameWidth, opts.OrgName, props.Text{
+			Top: 6, Size: 16, Style: fontstyle.Bold, Align: align.Left, Family: fontfamily.Arial, Color: ColorDarkGray,
+		}))
+		rows = append(rows, orgRow)
+	}
+
+	subtitle := "Vulnerability scan"
+	switch {
+	case len(report.Artifacts) == 1:
+		subtitle += " of " + report.Artifacts[0].Name
+	case report.Merged():
+		subtitle = fmt.Sprintf("Consolidated vulnerability scan of %d artifacts", len(report.Artifacts))
+	}
+	rows = append(rows,
+		text.NewRow(14, subtitle, props.Text{
+			Top: 4, Size: 14, Style: fontstyle.Bold, Align: align.Left, Family: fontfamily.Arial, Color: ColorHeaderOpen,
+		}),
+		row.New(4),
+	)
+
+	// --- Scanned artifacts ---
+	title := "SCANNED ARTIFACT"
+	if report.Merged() {
+		title += "S"
+	}
+	rows = append(rows, sectionHeaderRow(title))
+	for i, a := range report.Artifacts {
+		if i > 0 {
+			rows = append(rows, row.New(4))
+		}
+		for _, field := range artifactFields(a) {
+			rows = append(rows, coverFieldRow(field[0], field[1]))
+		}
+	}
+
+	// --- Provenance of the report itself ---
+	rows = append(rows, row.New(6), sectionHeaderRow("REPORT"))
+	rows = append(rows, coverFieldRow("Generated At", utils.FormatTime(&generatedAt)))
+	rows = append(rows, coverFieldRow("Generated By", "Trivy report plugin"))
+	if opts.OrgName != "" {
+		rows = append(rows, coverFieldRow("Prepared For", opts.OrgName))
+	}
+	if report.Filter.Active() {
+		rows = append(rows, coverFieldRow("Filters", strings.Join(report.Filter.Describe(), "; ")))
+	}
+	return rows
+}
+
+// artifactFields lists the label and value of every cover field of an artifact, "-" when unknown.
+func artifactFields(a model.Artifact) [][2]string {
+	osName := "-"
+	if a.Metadata.OS != nil {
+		osName = strings.TrimSpace(string(a.Metadata.OS.Family) + " " + a.Metadata.OS.Name)
+		if a.Metadata.OS.Eosl {
+			osName += " (end of service life)"
+		}
+	}
+	var createdAt *time.Time
+	if !a.CreatedAt.IsZero() {
+		createdAt = &a.CreatedAt
+	}
+	orDash := func(s string) string {
+		if s == "" {
+			return "-"
+		}
+		return s
+	}
+
+	return [][2]string{
+		{"Artifact", orDash(a.Name)},
+		{"Type", orDash(utils.SetArtifactType(a.Type))},
+		{"OS", osName},
+		{"Image ID", orDash(a.Metadata.ImageID)},
+		{"Repo Digests", orDash(strings.Join(a.Metadata.RepoDigests, ", "))},
+		{"Schema Version", fmt.Sprint(a.SchemaVersion)},
+		{"Scanned At", utils.FormatTime(createdAt)},
+	}
+}
+
+// coverFieldRow renders a label and its value as a bordered row.
+func coverFieldRow(label, value string) core.Row {
+	labelProp := bodyProp
+	labelProp.Style = fontstyle.Bold
+	labelProp.Size = 9
+	valueProp := bodyProp
+	valueProp.Size = 9
+
+	r := row.New(fitRowHeight([]string{label, value}, coverColWidths, valueProp))
+	r.WithStyle(&props.Cell{BorderType: border.Bottom, BorderColor: ColorLightGray})
+	r.Add(
+		wrapCol(coverColWidths[0], label, labelProp),
+		wrapCol(coverColWidths[1], value, valueProp),
+	)
+	return r
+}
+
+// scanTime returns the latest time an artifact of the report was scanned, or nil when none is recorded.
+func scanTime(report *model.Report) *time.Time {
+	var latest *time.Time
+	for i := range report.Artifacts {
+		createdAt := &report.Artifacts[i].CreatedAt
+		if !createdAt.IsZero() && (latest == nil || createdAt.After(*latest)) {
+			latest = createdAt
+		}
+	}
+	return latest
+}
@@ -0,0 +1,202 @@
+package pdf
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aquasecurity/trivy/pkg/types"
+	"github.com/johnfercher/maroto/v2/pkg/components/page"
+	"github.com/johnfercher/maroto/v2/pkg/components/row"
+	"github.com/johnfercher/maroto/v2/pkg/consts/border"
+	"github.com/johnfercher/maroto/v2/pkg/consts/fontstyle"
+	"github.com/johnfercher/maroto/v2/pkg/core"
+	"github.com/johnfercher/maroto/v2/pkg/props"
+	"trivy-plugin-excel/pkg/model"
+	"trivy-plugin-excel/pkg/utils"
+)
+
+var (
+	// LAYOUT: ID(3), Severity(2), Title(7) -> Total 12
+	detailHeadingColWidths = []int{3, 2, 7}
+	// LAYOUT: Label(2), Value(10) -> Total 12
+	detailColWidths = []int{2, 10}
+)
+
+// vulnDetail is an appendix entry: the advisory of a vulnerability ID and every package it was found in.
+type vulnDetail struct {
+	// vuln is the first finding of the ID, whose advisory fields (description, CVSS, dates...) are shown
+	vuln     types.DetectedVulnerability
+	packages []string
+	statuses []string
+}
+
+// detailID identifies the appendix entry of a vulnerability, the target of the links of its ID cells.
+func detailID(vulnID string) string {
+	return "vuln-" + vulnID
+}
+
+// collectVulnDetails returns one entry per vulnerability ID of the report, most severe first, then by ID.
+func collectVulnDetails(report *model.Report) []*vulnDetail {
+	byID := make(map[string]*vulnDetail)
+	var details []*vulnDetail
+	for _, result := range report.Results() {
+		for _, vuln := range result.Vulnerabilities {
+			d, ok := byID[vuln.VulnerabilityID]
+			if !ok {
+				d = &vulnDetail{vuln: vuln}
+				byID[vuln.VulnerabilityID] = d
+				details = append(details, d)
+			}
+			d.packages = appendUnique(d.packages, vuln.PkgName+" "+vuln.InstalledVersion)
+			d.statuses = appendUnique(d.statuses, vuln.Status.String())
+		}
+	}
+	sort.SliceStable(details, func(i, j int) bool {
+		wi, wj := getSeverityWeight(details[i].vuln.Severity), getSeverityWeight(details[j].vuln.Severity)
+		if wi != wj {
+			return wi > wj
+		}
+		return details[i].vuln.VulnerabilityID < details[j].vuln.VulnerabilityID
+	})
+	return details
+}
+
+// detailIDs returns the set of vulnerability IDs that have an appendix entry.
+func detailIDs(details []*vulnDetail) map[string]bool {
+	ids := make(map[string]bool, len(details))
+	for _, d := range details {
+		ids[d.vuln.VulnerabilityID] = true
+	}
+	return ids
+}
+
+// addDetailsAppendix renders a block per vulnerability on a new page: the full description, every
+// CVSS vector, CWE IDs, dates, the status explained and clickable references.
+func addDetailsAppendix(m core.Maroto, details []*vulnDetail) {
+	if len(details) == 0 {
+		return
+	}
+
+	rows := []core.Row{outlineSectionRow("APPENDIX: VULNERABILITY DETAILS")}
+	for _, d := range details {
+		rows = append(rows, row.New(4))
+		rows = append(rows, detailRows(d)...)
+	}
+	m.AddPages(page.New().Add(rows...))
+}
+
+// detailRows renders the block of one vulnerability, starting with its linked heading.
+func detailRows(d *vulnDetail) []core.Row {
+	vuln := d.vuln
+	idProp := bodyProp
+	idProp.Style = fontstyle.Bold
+	idProp.Size = 9
+	sevProp := idProp
+	sevProp.Color = getSeverityColor(vuln.Severity)
+	titleProp := bodyProp
+	titleProp.Style = fontstyle.Bold
+
+	title := strings.Join(strings.Fields(vuln.Title), " ")
+	heading := row.New(fitRowHeight([]string{"", "", title}, detailHeadingColWidths, titleProp))
+	heading.WithStyle(&props.Cell{BackgroundColor: getBackgroundColor(vuln.Severity)})
+	heading.Add(
+		wrapCol(detailHeadingColWidths[0], vuln.VulnerabilityID, idProp),
+		wrapCol(detailHeadingColWidths[1], vuln.Severity, sevProp),
+		wrapCol(detailHeadingColWidths[2], title, titleProp),
+	)
+	rows := []core.Row{withAnchor(heading, detailID(vuln.VulnerabilityID), "", 0)}
+
+	orDash := func(s string) string {
+		if s == "" {
+			return "-"
+		}
+		return s
+	}
+	rows = append(rows,
+		detailRow("Description", orDash(strings.Join(strings.Fields(vuln.Description), " ")), nil),
+		detailRow("CVSS", orDash(strings.Join(cvssLines(vuln), "; ")), nil),
+		detailRow("CWE", orDash(strings.Join(vuln.CweIDs, ", ")), nil),
+		detailRow("Published", utils.FormatTime(vuln.PublishedDate), nil),
+		detailRow("Last Modified", utils.FormatTime(vuln.LastModifiedDate), nil),
+	)
+
+	var statuses []string
+	for _, status := range d.statuses {
+		if description, ok := utils.VulnStatuses[status]; ok {
+			status += ": " + description
+		}
+		statuses = append(statuses, status)
+	}
+	rows = append(rows,
+		detailRow("Status", orDash(strings.Join(statuses, "; ")), nil),
+		detailRow("Packages", strings.Join(d.packages, ", "), nil),
+	)
+
+	references := vuln.References
+	if len(references) == 0 && vuln.PrimaryURL != "" {
+		references = []string{vuln.PrimaryURL}
+	}
+	for i, ref := range references {
+		label := ""
+		if i == 0 {
+			label = "References"
+		}
+		rows = append(rows, detailRow(label, ref, &ref))
+	}
+	return rows
+}
+
+// detailRow renders a label and its value; a non-nil link makes the value a hyperlink.
+func detailRow(label, value string, link *string) core.Row {
+	labelProp := bodyProp
+	labelProp.Style = fontstyle.Bold
+	valueProp := bodyProp
+	valueProp.Hyperlink = link
+
+	r := row.New(fitRowHeight([]string{label, value}, detailColWidths, bodyProp))
+	if label != "" {
+		r.WithStyle(&props.Cell{BorderType: border.Top, BorderColor: ColorBgHeader})
+	}
+	r.Add(
+		wrapCol(detailColWidths[0], label, labelProp),
+		wrapCol(detailColWidths[1], value, valueProp),
+	)
+	return r
+}
+
+// cvssLines describes every vendor's CVSS scores and vectors, in vendor order.
+func cvssLines(vuln types.DetectedVulnerability) []string {
+	var lines []string
+	for vendor, cvss := range vuln.CVSS {
+		var scores []string
+		if cvss.V40Score > 0 || cvss.V40Vector != "" {
+			scores = append(scores, fmt.Sprintf("v4.0 %.1f %s", cvss.V40Score, cvss.V40Vector))
+		}
+		if cvss.V3Score > 0 || cvss.V3Vector != "" {
+			scores = append(scores, fmt.Sprintf("v3 %.1f %s", cvss.V3Score, cvss.V3Vector))
+		}
+		if cvss.V2Score > 0 || cvss.V2Vector != "" {
+			scores = append(scores, fmt.Sprintf("v2 %.1f %s", cvss.V2Score, cvss.V2Vector))
+		}
+		if len(scores) > 0 {
+			lines = append(lines, fmt.Sprintf("%s: %s", vendor, strings.Join(scores, ", ")))
+		}
+	}
+	sort.Strings(lines)
+	return lines
+}
+
+// appendUnique appends s to list unless it is empty or already listed.
+func appendUnique(list []string, s string) []string {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return list
+	}
+	for _, item := range list {
+		if item == s {
+			return list
+		}
+	}
+	return append(list, s)
+}
@@ -0,0 +1,84 @@
+package pdf
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/johnfercher/maroto/v2/pkg/components/page"
+	"github.com/johnfercher/maroto/v2/pkg/components/row"
+	"github.com/johnfercher/maroto/v2/pkg/components/text"
+	"github.com/johnfercher/maroto/v2/pkg/consts/align"
+	"github.com/johnfercher/maroto/v2/pkg/consts/fontfamily"
+	"github.com/johnfercher/maroto/v2/pkg/consts/fontstyle"
+	"github.com/johnfercher/maroto/v2/pkg/core"
+	"github.com/johnfercher/maroto/v2/pkg/props"
+	"trivy-plugin-excel/pkg/model"
+)
+
+var (
+	// LAYOUT: Name(3), Version(2), Licenses(2), PURL(4), Vulns(1) -> Total 12
+	inventoryHeaders   = []string{"Package", "Version", "Licenses", "PURL", "Vulns"}
+	inventoryColWidths = []int{3, 2, 2, 4, 1}
+)
+
+// addInventoryAppendix renders every package of the report on a new page, grouped by target.
+// Nothing is added when the report has no package inventory (trivy --list-all-pkgs).
+func addInventoryAppendix(m core.Maroto, report *model.Report) {
+	if !report.HasPackages() {
+		return
+	}
+
+	rows := []core.Row{outlineSectionRow("APPENDIX: PACKAGE INVENTORY")}
+
+	target := ""
+	for _, item := range report.Inventory() {
+		if key := item.Artifact + "\x00" + item.Target; key != target {
+			target = key
+			rows = append(rows,
+				text.NewRow(12, fmt.Sprintf("Target: %s (%s)", item.Target, item.Class), props.Text{
+					Top:    3,
+					Style:  fontstyle.Bold,
+					Size:   10,
+					Family: fontfamily.Arial,
+					Color:  ColorDarkGray,
+					Align:  align.Left,
+				}),
+				tableHeaderRow(inventoryHeaders, inventoryColWidths),
+			)
+		}
+
+		pkg := item.Package
+		purl := ""
+		if pkg.Identifier.PURL != nil {
+			purl = pkg.Identifier.PURL.String()
+		}
+		cells := []string{
+			pkg.Name,
+			model.PackageVersion(pkg),
+			strings.Join(pkg.Licenses, ", "),
+			purl,
+			fmt.Sprint(item.Vulnerabilities),
+		}
+
+		r := row.New(fitRowHeight(cells, inventoryColWidths, bodyProp))
+		r.WithStyle(&props.Cell{BackgroundColor: getBackgroundColor(item.MaxSeverity)})
+
+		countProp := bodyProp
+		countProp.Align = align.Center
+		if item.Vulnerabilities > 0 {
+			countProp.Style = fontstyle.Bold
+			countProp.Color = getSeverityColor(item.MaxSeverity)
+		}
+
+		for i, cell := range cells {
+			prop := bodyProp
+			if i == len(cells)-1 {
+				prop = countProp
+			}
+			r.Add(wrapCol(inventoryColWidths[i], cell, prop))
+		}
+		rows = append(rows, r)
+	}
+
+	m.AddPages(page.New().Add(rows...))
+}
@@ -0,0 +1,130 @@
+package pdf
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aquasecurity/trivy/pkg/types"
+	"github.com/johnfercher/maroto/v2/pkg/components/page"
+	"github.com/johnfercher/maroto/v2/pkg/components/row"
+	"github.com/johnfercher/maroto/v2/pkg/components/text"
+	"github.com/johnfercher/maroto/v2/pkg/consts/align"
+	"github.com/johnfercher/maroto/v2/pkg/consts/border"
+	"github.com/johnfercher/maroto/v2/pkg/consts/fontfamily"
+	"github.com/johnfercher/maroto/v2/pkg/consts/fontstyle"
+	"github.com/johnfercher/maroto/v2/pkg/core"
+	"github.com/johnfercher/maroto/v2/pkg/props"
+	"trivy-plugin-excel/pkg/model"
+)
+
+var (
+	// LAYOUT: Category(2), Findings(1), Licenses(9) -> Total 12
+	complianceHeaders   = []string{"Category", "Findings", "Licenses"}
+	complianceColWidths = []int{2, 1, 9}
+
+	// LAYOUT: Sev(1), Category(2), Package/File(3), License(3), Confidence(1), Target(2) -> Total 12
+	licenseHeaders   = []string{"Severity", "Category", "Package / File", "License", "Confidence", "Target"}
+	licenseColWidths = []int{1, 2, 3, 3, 1, 2}
+)
+
+// addLicensePage renders the license compliance page: counts per category, then every license finding.
+// Nothing is added when the report has no license findings.
+func addLicensePage(m core.Maroto, report *model.Report) {
+	if !report.HasLicenses() {
+		return
+	}
+
+	var rows []core.Row
+	rows = append(rows, outlineSectionRow("LICENSE COMPLIANCE"), row.New(4))
+
+	// --- Compliance summary ---
+	rows = append(rows, tableHeaderRow(complianceHeaders, complianceColWidths))
+	for _, count := range report.LicenseSummary() {
+		severity := model.LicenseSeverity(count.Category)
+		countProp := bodyProp
+		countProp.Align = align.Center
+		countProp.Style = fontstyle.Bold
+		if count.Findings > 0 {
+			countProp.Color = getSeverityColor(severity)
+		}
+
+		names := strings.Join(count.Licenses, ", ")
+		if names == "" {
+			names = "-"
+		}
+		cells := []string{string(count.Category), fmt.Sprint(count.Findings), names}
+
+		r := row.New(fitRowHeight(cells, complianceColWidths, bodyProp))
+		r.WithStyle(&props.Cell{BorderType: border.Bottom, BorderColor: ColorLightGray})
+		r.Add(
+			wrapCol(complianceColWidths[0], cells[0], bodyProp),
+			wrapCol(complianceColWidths[1], cells[1], countProp),
+			wrapCol(complianceColWidths[2], cells[2], bodyProp),
+		)
+		rows = append(rows, r)
+	}
+	rows = append(rows, row.New(10))
+
+	// --- License findings ---
+	rows = append(rows,
+		text.NewRow(10, "License Findings", props.Text{
+			Top:    2,
+			Style:  fontstyle.Bold,
+			Size:   10,
+			Family: fontfamily.Arial,
+			Color:  ColorDarkGray,
+			Align:  align.Left,
+		}),
+		tableHeaderRow(licenseHeaders, licenseColWidths),
+	)
+
+	type licenseFinding struct {
+		target  string
+		license types.DetectedLicense
+	}
+	var findings []licenseFinding
+	for _, result := range report.Results() {
+		for _, license := range result.Licenses {
+			findings = append(findings, licenseFinding{target: result.Target, license: license})
+		}
+	}
+	sort.SliceStable(findings, func(i, j int) bool {
+		return getSeverityWeight(findings[i].license.Severity) > getSeverityWeight(findings[j].license.Severity)
+	})
+
+	for _, finding := range findings {
+		license := finding.license
+		subject := license.PkgName
+		if subject == "" {
+			subject = license.FilePath
+		}
+		cells := []string{
+			license.Severity,
+			string(license.Category),
+			subject,
+			license.Name,
+			fmt.Sprintf("%.0f%%", license.Confidence*100),
+			finding.target,
+		}
+
+		r := row.New(fitRowHeight(cells, licenseColWidths, bodyProp))
+		r.WithStyle(&props.Cell{BackgroundColor: getBackgroundColor(license.Severity)})
+
+		sevProp := bodyProp
+		sevProp.Style = fontstyle.Bold
+		sevProp.Color = getSeverityColor(license.Severity)
+		sevProp.Align = align.Center
+
+		for i, cell := range cells {
+			prop := bodyProp
+			if i == 0 {
+				prop = sevProp
+			}
+			r.Add(wrapCol(licenseColWidths[i], cell, prop))
+		}
+		rows = append(rows, r)
+	}
+
+	m.AddPages(page.New().Add(rows...))
+}
@@ -0,0 +1,157 @@
+package pdf
+
+import (
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/johnfercher/maroto/v2/pkg/components/col"
+	"github.com/johnfercher/maroto/v2/pkg/components/text"
+	"github.com/johnfercher/maroto/v2/pkg/consts/fontfamily"
+	"github.com/johnfercher/maroto/v2/pkg/core"
+	"github.com/johnfercher/maroto/v2/pkg/props"
+	"github.com/phpdave11/gofpdf"
+)
+
+const (
+	// cellPadding is the space in mm below the last line of a cell; the Top of the text pads above the first
+	cellPadding = 2.0
+	// lineSpacing is the distance between wrapped lines as a multiple of the font height
+	lineSpacing = 1.2
+	// defaultFontSize is the size maroto renders text without an explicit size in
+	defaultFontSize = 10.0
+	// breakAfter lists the characters a token too wide for its column is preferably broken after,
+	// such as the slashes of module paths and the separators of package versions
+	breakAfter = "/.-_:@+~=&?,;"
+)
+
+// fontMetrics measures text with the core font metrics maroto renders with.
+// gofpdf keeps the current font as state, hence the lock.
+var fontMetrics struct {
+	sync.Mutex
+	pdf       *gofpdf.Fpdf
+	translate func(string) string
+}
+
+// textWidth returns the width in mm of s set in the font of prop.
+func textWidth(s string, prop props.Text) float64 {
+	fontMetrics.Lock()
+	defer fontMetrics.Unlock()
+
+	if fontMetrics.pdf == nil {
+		fontMetrics.pdf = gofpdf.New("L", "mm", "A4", "")
+		// Core fonts are set in cp1252, which maroto translates text to before measuring it
+		fontMetrics.translate = fontMetrics.pdf.UnicodeTranslatorFromDescriptor("")
+	}
+	family := prop.Family
+	if family == "" {
+		family = fontfamily.Arial
+	}
+	fontMetrics.pdf.SetFont(family, string(prop.Style), fontSize(prop))
+	return fontMetrics.pdf.GetStringWidth(fontMetrics.translate(s))
+}
+
+// fontSize returns the size in points text of prop is rendered in.
+func fontSize(prop props.Text) float64 {
+	if prop.Size == 0 {
+		return defaultFontSize
+	}
+	return prop.Size
+}
+
+// lineHeight returns the distance in mm between wrapped lines of prop.
+func lineHeight(prop props.Text) float64 {
+	return fontSize(prop) * 25.4 / 72 * lineSpacing
+}
+
+// gridWidth returns the width in mm of a column spanning size grid units.
+func gridWidth(size int) float64 {
+	return contentWidth * float64(size) / 12
+}
+
+// wrapText breaks s into lines no wider than width: on spaces first, like maroto does, then within
+// words too wide for a line on their own, preferably after a separator such as "/" or "-".
+// Runs of whitespace collapse to a single space. An empty s has no lines.
+func wrapText(s string, width float64, prop props.Text) []string {
+	var lines []string
+	line := ""
+	for _, word := range strings.Fields(s) {
+		if line != "" {
+			if candidate := line + " " + word; textWidth(candidate, prop) <= width {
+				line = candidate
+				continue
+			}
+			lines = append(lines, line)
+		}
+		for textWidth(word, prop) > width {
+			head, tail := splitToken(word, width, prop)
+			lines = append(lines, head)
+			word = tail
+		}
+		line = word
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// splitToken cuts the longest head off token that fits in width, ending at the last separator within
+// it when there is one. The head keeps at least one rune, so wrapping always progresses.
+func splitToken(token string, width float64, prop props.Text) (head, tail string) {
+	fit, lastBreak := 0, 0
+	for i, r := range token {
+		end := i + utf8.RuneLen(r)
+		if fit > 0 && textWidth(token[:end], prop) > width {
+			break
+		}
+		fit = end
+		if strings.ContainsRune(breakAfter, r) && end < len(token) {
+			lastBreak = end
+		}
+	}
+	if lastBreak > 0 {
+		fit = lastBreak
+	}
+	return token[:fit], token[fit:]
+}
+
+// addLines adds each line to c as its own text, the first at prop.Top, so maroto draws exactly the lines
+// that were measured. It returns the offset in mm below the last line.
+func addLines(c core.Col, lines []string, prop props.Text) float64 {
+	top := prop.Top
+	for _, line := range lines {
+		p := prop
+		p.Top = top
+		c.Add(text.New(line, p))
+		top += lineHeight(prop)
+	}
+	return top
+}
+
+// wrapCol builds a column of size grid units holding s wrapped to its width.
+func wrapCol(size int, s string, prop props.Text) core.Col {
+	c := col.New(size)
+	addLines(c, wrapText(s, gridWidth(size)-prop.Left-prop.Right, prop), prop)
+	return c
+}
+
+// cellHeight returns the height in mm of a cell of size grid units holding s, at least one line tall.
+func cellHeight(s string, size int, prop props.Text) float64 {
+	lines := len(wrapText(s, gridWidth(size)-prop.Left-prop.Right, prop))
+	if lines < 1 {
+		lines = 1
+	}
+	return prop.Top + float64(lines)*lineHeight(prop) + cellPadding
+}
+
+// fitRowHeight returns the height of a table row fitting every cell once wrapped to its column width.
+func fitRowHeight(cells []string, widths []int, prop props.Text) float64 {
+	height := 0.0
+	for i, cell := range cells {
+		if h := cellHeight(cell, widths[i], prop); h > height {
+			height = h
+		}
+	}
+	return height
+}
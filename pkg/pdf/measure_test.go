@@ -0,0 +1,210 @@
+package pdf
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/types"
+	"github.com/johnfercher/go-tree/node"
+	"github.com/johnfercher/maroto/v2/pkg/consts/fontstyle"
+	"github.com/johnfercher/maroto/v2/pkg/core"
+	"github.com/johnfercher/maroto/v2/pkg/props"
+	"trivy-plugin-excel/pkg/exporter"
+	"trivy-plugin-excel/pkg/model"
+)
+
+// pathological are strings that overflowed or left large gaps with character-count row heights.
+var pathological = map[string]string{
+	"debian version":  "1:2.36.1-8+deb12u1~really2.36.1-8+deb12u1+b1~bpo11+1.really.long.epoch.revision",
+	"ubuntu version":  "2.35-0ubuntu3.8+esm2~22.04.1ubuntu0.1+security+hardening+build1",
+	"go module path":  "github.com/aws/aws-sdk-go-v2/service/s3/internal/customizations/presigned/v2",
+	"npm scoped path": "@babel/plugin-transform-modules-commonjs/node_modules/@babel/helper-module-transforms",
+	"maven coords":    "org.apache.logging.log4j:log4j-core-jakarta-bundle-with-extras:2.17.1.redhat-00001",
+	"url":             "https://github.com/advisories/GHSA-xxxx-yyyy-zzzz?query=very_long_parameter&other=value&more=1",
+	"unbroken token":  strings.Repeat("W", 200),
+	"wide glyphs":     strings.Repeat("MW@%", 40),
+	"narrow glyphs":   strings.Repeat("il1.", 80),
+	"long title": "glibc: buffer overflow in the iconv conversion of ISO-2022-CN-EXT that lets a local attacker " +
+		"crash the process or execute arbitrary code via specially crafted input to the character set conversion routines",
+	"accents":    "Überprüfung fehlgeschlagen: ungültige Zeichenkette in élément très très très très très long",
+	"whitespace": "  leading\tand   trailing \n whitespace  ",
+	"short":      "ok",
+	"empty":      "",
+}
+
+// measureProps are the text properties wrapped cells are rendered in.
+func measureProps() map[string]props.Text {
+	bold := bodyProp
+	bold.Style = fontstyle.Bold
+	small := bodyProp
+	small.Size = 6.5
+	indented := bodyProp
+	indented.Left = 4
+	return map[string]props.Text{"body": bodyProp, "bold": bold, "small": small, "indented": indented}
+}
+
+func TestWrapTextFitsWidth(t *testing.T) {
+	for name, s := range pathological {
+		for propName, prop := range measureProps() {
+			for _, size := range []int{1, 2, 3, 10} {
+				width := gridWidth(size) - prop.Left - prop.Right
+				lines := wrapText(s, width, prop)
+
+				for _, line := range lines {
+					if w := textWidth(line, prop); w > width {
+						t.Errorf("%s/%s/%d: line %q is %.2f mm wide, column fits %.2f mm", name, propName, size, line, w, width)
+					}
+					if line == "" || strings.TrimSpace(line) != line {
+						t.Errorf("%s/%s/%d: line %q is blank or padded", name, propName, size, line)
+					}
+				}
+				// Wrapping only drops whitespace
+				if got, want := strings.Join(strings.Fields(strings.Join(lines, "")), ""), strings.Join(strings.Fields(s), ""); got != want {
+					t.Errorf("%s/%s/%d: wrapped text %q, want %q", name, propName, size, got, want)
+				}
+				if blank := strings.TrimSpace(s) == ""; blank != (len(lines) == 0) {
+					t.Errorf("%s/%s/%d: %d lines for %q", name, propName, size, len(lines), s)
+				}
+			}
+		}
+	}
+}
+
+func TestWrapTextBreaksAfterSeparators(t *testing.T) {
+	width := gridWidth(2) - bodyProp.Left - bodyProp.Right
+	lines := wrapText(pathological["go module path"], width, bodyProp)
+	if len(lines) < 2 {
+		t.Fatalf("module path fits on %d line, want it wrapped", len(lines))
+	}
+	for _, line := range lines[:len(lines)-1] {
+		if !strings.HasSuffix(line, "/") {
+			t.Errorf("line %q does not end at a path separator", line)
+		}
+	}
+
+	// Text with spaces wraps on them, like maroto
+	lines = wrapText("openssl: denial of service", gridWidth(1), bodyProp)
+	for _, line := range lines {
+		if strings.HasPrefix(line, " ") || strings.Contains(line, "  ") {
+			t.Errorf("line %q is not broken on a space", line)
+		}
+	}
+	if got := strings.Join(lines, " "); got != "openssl: denial of service" {
+		t.Errorf("lines %q, want the words kept whole", lines)
+	}
+}
+
+func TestFitRowHeight(t *testing.T) {
+	oneLine := bodyProp.Top + lineHeight(bodyProp) + cellPadding
+	if got := fitRowHeight([]string{"", "ok"}, []int{2, 2}, bodyProp); got != oneLine {
+		t.Errorf("short row is %.2f mm, want one line of %.2f mm", got, oneLine)
+	}
+
+	for name, s := range pathological {
+		lines := len(wrapText(s, gridWidth(2)-bodyProp.Left-bodyProp.Right, bodyProp))
+		if lines < 1 {
+			lines = 1
+		}
+		want := bodyProp.Top + float64(lines)*lineHeight(bodyProp) + cellPadding
+		if got := fitRowHeight([]string{"ok", s}, []int{10, 2}, bodyProp); got != want {
+			t.Errorf("%s: row is %.2f mm, want %d lines of %.2f mm", name, got, lines, want)
+		}
+	}
+
+	// The tallest cell sets the height, and narrower columns only make rows taller
+	title := pathological["long title"]
+	if narrow, wide := fitRowHeight([]string{title}, []int{2}, bodyProp), fitRowHeight([]string{title}, []int{6}, bodyProp); narrow <= wide {
+		t.Errorf("title in 2 units is %.2f mm, in 6 units %.2f mm; want taller when narrower", narrow, wide)
+	}
+	if got, want := fitRowHeight([]string{"ok", title}, []int{3, 3}, bodyProp), cellHeight(title, 3, bodyProp); got != want {
+		t.Errorf("row is %.2f mm, want the %.2f mm of its tallest cell", got, want)
+	}
+}
+
+// pathologicalReport spreads the pathological strings over every table cell that wraps.
+func pathologicalReport() *model.Report {
+	var vulns []types.DetectedVulnerability
+	var packages []ftypes.Package
+	i := 0
+	for name, s := range pathological {
+		i++
+		vuln := types.DetectedVulnerability{
+			VulnerabilityID:  fmt.Sprintf("CVE-2024-%05d", i),
+			PkgName:          s,
+			InstalledVersion: pathological["debian version"],
+			FixedVersion:     s,
+			PrimaryURL:       pathological["url"],
+		}
+		vuln.Title = name + ": " + s
+		vuln.Description = pathological["long title"] + " " + s
+		vuln.References = []string{pathological["url"], s}
+		vuln.Severity = model.Severities[i%len(model.Severities)]
+		vuln.CVSS = dbTypes.VendorCVSS{"nvd": {V3Score: 9.8, V3Vector: "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H/E:F/RL:O/RC:C/CR:H/IR:H/AR:H"}}
+		vulns = append(vulns, vuln)
+		packages = append(packages, ftypes.Package{Name: s, Version: pathological["ubuntu version"]})
+	}
+	result := types.Result{Target: pathological["npm scoped path"], Class: types.ClassLangPkg, Type: "npm", Packages: packages, Vulnerabilities: vulns}
+	return model.New(&types.Report{ArtifactName: pathological["go module path"], Results: types.Results{result}})
+}
+
+// checkLayout fails for every text of a table row of m wider than its column or lower than its row.
+func checkLayout(t *testing.T, m core.Maroto) {
+	cfg := m.GetCurrentConfig()
+	width := cfg.Dimensions.Width - cfg.Margins.Left - cfg.Margins.Right
+	for p, page := range m.GetStructure().GetNexts() {
+		for _, r := range page.GetNexts() {
+			height, _ := r.GetData().Value.(float64)
+			for _, c := range r.GetNexts() {
+				colWidth := width
+				if size, _ := c.GetData().Value.(int); size > 0 {
+					colWidth = width * float64(size) / float64(cfg.MaxGridSize)
+				}
+				for _, component := range c.GetNexts() {
+					checkText(t, p+1, component, colWidth, height)
+				}
+			}
+		}
+	}
+}
+
+func checkText(t *testing.T, page int, component *node.Node[core.Structure], colWidth, rowHeight float64) {
+	data := component.GetData()
+	if data.Type != "text" {
+		return
+	}
+	prop := props.Text{}
+	prop.Top, _ = data.Details["prop_top"].(float64)
+	prop.Left, _ = data.Details["prop_left"].(float64)
+	prop.Right, _ = data.Details["prop_right"].(float64)
+	prop.Family, _ = data.Details["prop_font_family"].(string)
+	prop.Style, _ = data.Details["prop_font_style"].(fontstyle.Type)
+	prop.Size, _ = data.Details["prop_font_size"].(float64)
+
+	s := fmt.Sprint(data.Value)
+	if w := textWidth(s, prop); w > colWidth-prop.Left-prop.Right {
+		t.Errorf("page %d: %q is %.2f mm wide in a %.2f mm column", page, s, w, colWidth)
+	}
+	if bottom := prop.Top + fontSize(prop)*25.4/72; rowHeight > 0 && bottom > rowHeight {
+		t.Errorf("page %d: %q ends %.2f mm down a %.2f mm row", page, s, bottom, rowHeight)
+	}
+}
+
+func TestExportPathologicalStrings(t *testing.T) {
+	report := pathologicalReport()
+	opts := exporter.Options{DetailsAppendix: true, InventoryAppendix: true}
+
+	m, err := build(report, opts, time.Now(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkLayout(t, m)
+
+	if err := Export(report, filepath.Join(t.TempDir(), "report.pdf"), opts); err != nil {
+		t.Fatal(err)
+	}
+}
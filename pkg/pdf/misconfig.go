@@ -0,0 +1,132 @@
+package pdf
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aquasecurity/trivy/pkg/types"
+	"github.com/johnfercher/maroto/v2/pkg/components/row"
+	"github.com/johnfercher/maroto/v2/pkg/components/text"
+	"github.com/johnfercher/maroto/v2/pkg/consts/align"
+	"github.com/johnfercher/maroto/v2/pkg/consts/fontfamily"
+	"github.com/johnfercher/maroto/v2/pkg/consts/fontstyle"
+	"github.com/johnfercher/maroto/v2/pkg/core"
+	"github.com/johnfercher/maroto/v2/pkg/props"
+	"trivy-plugin-excel/pkg/model"
+	"trivy-plugin-excel/pkg/utils"
+)
+
+var (
+	// LAYOUT: ID(2), Sev(1), Title(3), Cause(2), Resolution(3), Status(1) -> Total 12
+	misconfHeaders   = []string{"ID", "Severity", "Title", "Cause", "Resolution", "Status"}
+	misconfColWidths = []int{2, 1, 3, 2, 3, 1}
+)
+
+// addMisconfigSection renders the misconfiguration findings of every target that has any.
+func addMisconfigSection(m core.Maroto, report *model.Report) {
+	started := false
+	for _, artifact := range report.Artifacts {
+		for _, result := range artifact.Results {
+			if len(result.Misconfigurations) == 0 {
+				continue
+			}
+			if !started {
+				addSectionHeader(m, "MISCONFIGURATIONS")
+				started = true
+			}
+
+			m.AddRows(
+				text.NewRow(12, fmt.Sprintf("Target: %s (%s)", result.Target, utils.SetResultClass(result.Class)), props.Text{
+					Top:    3,
+					Style:  fontstyle.Bold,
+					Size:   10,
+					Family: fontfamily.Arial,
+					Color:  ColorDarkGray,
+					Align:  align.Left,
+				}),
+			)
+			addMisconfTable(m, result.Misconfigurations)
+		}
+	}
+}
+
+// addMisconfTable renders the misconfiguration table header and one row per finding, most severe first.
+func addMisconfTable(m core.Maroto, misconfs []types.DetectedMisconfiguration) {
+	m.AddRows(tableHeaderRow(misconfHeaders, misconfColWidths))
+
+	sorted := append([]types.DetectedMisconfiguration(nil), misconfs...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return getSeverityWeight(sorted[i].Severity) > getSeverityWeight(sorted[j].Severity)
+	})
+
+	for _, misconf := range sorted {
+		cells := []string{
+			misconfID(misconf),
+			misconf.Severity,
+			strings.ReplaceAll(misconf.Title, "\n", " "),
+			formatCause(misconf),
+			strings.ReplaceAll(misconf.Resolution, "\n", " "),
+			string(misconf.Status),
+		}
+
+		r := row.New(fitRowHeight(cells, misconfColWidths, bodyProp))
+		r.WithStyle(&props.Cell{BackgroundColor: getBackgroundColor(misconf.Severity)})
+
+		sevProp := bodyProp
+		sevProp.Style = fontstyle.Bold
+		sevProp.Color = getSeverityColor(misconf.Severity)
+		sevProp.Align = align.Center
+
+		// The ID links to the check's documentation, like the vulnerability IDs link to their details
+		idProp := bodyProp
+		if misconf.PrimaryURL != "" {
+			url := misconf.PrimaryURL
+			idProp.Hyperlink = &url
+		}
+
+		for i, cell := range cells {
+			prop := bodyProp
+			switch i {
+			case 0:
+				prop = idProp
+			case 1:
+				prop = sevProp
+			}
+			r.Add(wrapCol(misconfColWidths[i], cell, prop))
+		}
+		m.AddRows(r)
+	}
+	m.AddRows(row.New(6))
+}
+
+// misconfID shows both the Aqua vulnerability database ID and the check ID, e.g. "AVD-DS-0002 (DS002)".
+func misconfID(misconf types.DetectedMisconfiguration) string {
+	switch {
+	case misconf.AVDID == "":
+		return misconf.ID
+	case misconf.ID == "" || misconf.ID == misconf.AVDID:
+		return misconf.AVDID
+	default:
+		return fmt.Sprintf("%s (%s)", misconf.AVDID, misconf.ID)
+	}
+}
+
+// formatCause summarizes where a misconfiguration was found, e.g. "aws/s3 my-bucket (lines 3-9)".
+func formatCause(misconf types.DetectedMisconfiguration) string {
+	cause := misconf.CauseMetadata
+	var parts []string
+	if cause.Provider != "" || cause.Service != "" {
+		parts = append(parts, strings.Trim(strings.ToLower(cause.Provider)+"/"+cause.Service, "/"))
+	}
+	if cause.Resource != "" {
+		parts = append(parts, cause.Resource)
+	}
+	if lines := utils.FormatLineRange(cause.StartLine, cause.EndLine); lines != "" {
+		parts = append(parts, "(lines "+lines+")")
+	}
+	if len(parts) == 0 {
+		return "-"
+	}
+	return strings.Join(parts, " ")
+}
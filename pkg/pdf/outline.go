@@ -0,0 +1,240 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/johnfercher/go-tree/node"
+	"github.com/johnfercher/maroto/v2/pkg/components/row"
+	"github.com/johnfercher/maroto/v2/pkg/components/text"
+	"github.com/johnfercher/maroto/v2/pkg/consts/align"
+	"github.com/johnfercher/maroto/v2/pkg/consts/border"
+	"github.com/johnfercher/maroto/v2/pkg/consts/fontstyle"
+	"github.com/johnfercher/maroto/v2/pkg/core"
+	"github.com/johnfercher/maroto/v2/pkg/core/entity"
+	"github.com/johnfercher/maroto/v2/pkg/props"
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	pdfmodel "github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"trivy-plugin-excel/pkg/model"
+)
+
+// anchorType is the structure type of anchors, told apart from maroto's own components
+const anchorType = "anchor"
+
+// LAYOUT: Target(8), Findings(2), Page(2) -> Total 12
+var tocColWidths = []int{8, 2, 2}
+
+// anchor is an invisible component marking a heading, so the page it lands on can be looked up
+// once maroto has laid out the pages. Titled anchors become bookmarks of the PDF outline;
+// anchors with a link make their column a link to the anchor with that id.
+type anchor struct {
+	id    string
+	title string
+	// level nests the bookmark: 0 for sections and artifacts, 1 for the targets of a merged artifact
+	level int
+	link  string
+}
+
+func (a *anchor) Render(core.Provider, *entity.Cell)            {}
+func (a *anchor) GetHeight(core.Provider, *entity.Cell) float64 { return 0 }
+func (a *anchor) SetConfig(*entity.Config)                      {}
+
+func (a *anchor) GetStructure() *node.Node[core.Structure] {
+	return node.New(core.Structure{
+		Type:    anchorType,
+		Value:   a.id,
+		Details: map[string]interface{}{"title": a.title, "level": a.level, "link": a.link},
+	})
+}
+
+// withAnchor marks r with an anchor in its first column.
+func withAnchor(r core.Row, id, title string, level int) core.Row {
+	r.GetColumns()[0].Add(&anchor{id: id, title: title, level: level})
+	return r
+}
+
+// linkCol makes c a link to the anchor with the given id.
+func linkCol(c core.Col, id string) core.Col {
+	return c.Add(&anchor{link: id})
+}
+
+// placedAnchor is an anchor with the page, counted from 1, and the area of the column it was laid out in.
+type placedAnchor struct {
+	anchor
+	page int
+	// x, y, width and height are in mm from the top left corner of the page
+	x, y, width, height float64
+}
+
+// layoutAnchors lays out the pages of m and returns its anchors in document order.
+// Laying out closes the last page of m, so m must not be generated afterwards.
+func layoutAnchors(m core.Maroto) []placedAnchor {
+	cfg := m.GetCurrentConfig()
+	contentWidth := cfg.Dimensions.Width - cfg.Margins.Left - cfg.Margins.Right
+
+	var anchors []placedAnchor
+	for i, p := range m.GetStructure().GetNexts() {
+		y := cfg.Margins.Top
+		for _, r := range p.GetNexts() {
+			height, _ := r.GetData().Value.(float64)
+			x := cfg.Margins.Left
+			for _, c := range r.GetNexts() {
+				width := contentWidth
+				if size, _ := c.GetData().Value.(int); size > 0 {
+					width = contentWidth * float64(size) / float64(cfg.MaxGridSize)
+				}
+				for _, component := range c.GetNexts() {
+					data := component.GetData()
+					if data.Type != anchorType {
+						continue
+					}
+					a := placedAnchor{anchor: anchor{id: fmt.Sprint(data.Value)}, page: i + 1, x: x, y: y, width: width, height: height}
+					a.title, _ = data.Details["title"].(string)
+					a.level, _ = data.Details["level"].(int)
+					a.link, _ = data.Details["link"].(string)
+					anchors = append(anchors, a)
+				}
+				x += width
+			}
+			y += height
+		}
+	}
+	return anchors
+}
+
+// anchorPages indexes the pages of placed anchors by id.
+func anchorPages(anchors []placedAnchor) map[string]int {
+	pages := make(map[string]int, len(anchors))
+	for _, a := range anchors {
+		if a.id != "" {
+			pages[a.id] = a.page
+		}
+	}
+	return pages
+}
+
+// targetID identifies the anchor of the n-th target heading, counted across artifacts.
+func targetID(n int) string {
+	return fmt.Sprintf("target-%d", n)
+}
+
+// tocRows lists every target with a vulnerability table, grouped by artifact in merged reports, with its number
+// of findings and the page it starts on. pages is nil on the layout pass, which leaves the page numbers blank.
+func tocRows(report *model.Report, pages map[string]int) []core.Row {
+	if len(report.Artifacts) == 0 {
+		return nil
+	}
+
+	rows := []core.Row{
+		outlineSectionRow("CONTENTS"),
+		row.New(2),
+		tableHeaderRow([]string{"Target", "Findings", "Page"}, tocColWidths),
+	}
+	artifactProp := bodyProp
+	artifactProp.Style = fontstyle.Bold
+	countProp := bodyProp
+	countProp.Align = align.Center
+
+	n := 0
+	for ai := range report.Artifacts {
+		artifact := &report.Artifacts[ai]
+		targetProp := bodyProp
+		if report.Merged() {
+			rows = append(rows, text.NewRow(8, "Artifact: "+artifact.Name, artifactProp))
+			targetProp.Left = 4
+		}
+		for _, result := range vulnResults(artifact) {
+			n++
+			page := ""
+			if p, ok := pages[targetID(n)]; ok {
+				page = fmt.Sprint(p)
+			}
+			target := fmt.Sprintf("%s (%s)", result.Target, result.Class)
+			count := fmt.Sprint(len(result.Vulnerabilities))
+
+			// The page column is left out of the height so both passes lay the contents out alike
+			r := row.New(fitRowHeight([]string{target, count}, tocColWidths[:2], targetProp))
+			r.WithStyle(&props.Cell{BorderType: border.Bottom, BorderColor: ColorLightGray})
+			r.Add(
+				wrapCol(tocColWidths[0], target, targetProp),
+				wrapCol(tocColWidths[1], count, countProp),
+				wrapCol(tocColWidths[2], page, countProp),
+			)
+			rows = append(rows, r)
+		}
+	}
+	if n == 0 {
+		return nil
+	}
+	return rows
+}
+
+// saveWithNavigation writes the PDF to path with a bookmark for every titled anchor, nesting level 1
+// bookmarks under the level 0 bookmark before them, and a link for every linking anchor.
+// pageHeight, in mm, turns the anchor positions into PDF coordinates.
+func saveWithNavigation(pdf []byte, path string, anchors []placedAnchor, pageHeight float64) error {
+	var bookmarks []pdfcpu.Bookmark
+	targets := make(map[string]placedAnchor)
+	for _, a := range anchors {
+		if a.id != "" {
+			targets[a.id] = a
+		}
+		if a.title == "" {
+			continue
+		}
+		bm := pdfcpu.Bookmark{Title: a.title, PageFrom: a.page}
+		if a.level > 0 && len(bookmarks) > 0 {
+			parent := &bookmarks[len(bookmarks)-1]
+			parent.Kids = append(parent.Kids, bm)
+			continue
+		}
+		bookmarks = append(bookmarks, bm)
+	}
+
+	// PDF coordinates are in points from the bottom left corner
+	toPoints := func(mm float64) float64 { return mm * 72 / 25.4 }
+	links := make(map[int][]pdfmodel.AnnotationRenderer)
+	for _, a := range anchors {
+		target, ok := targets[a.link]
+		if a.link == "" || !ok {
+			continue
+		}
+		rect := types.NewRectangle(toPoints(a.x), toPoints(pageHeight-a.y-a.height), toPoints(a.x+a.width), toPoints(pageHeight-a.y))
+		dest := &pdfmodel.Destination{Typ: pdfmodel.DestXYZ, PageNr: target.page, Top: int(toPoints(pageHeight - target.y))}
+		links[a.page] = append(links[a.page], pdfmodel.NewLinkAnnotation(*rect, nil, dest, "", "", 0, nil, false))
+	}
+
+	if len(bookmarks) == 0 && len(links) == 0 {
+		return os.WriteFile(path, pdf, 0o644)
+	}
+
+	conf := pdfmodel.NewDefaultConfiguration()
+	conf.ValidationMode = pdfmodel.ValidationRelaxed
+	ctx, _, _, _, err := api.ReadValidateAndOptimize(bytes.NewReader(pdf), conf, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to read PDF for navigation: %w", err)
+	}
+	if err := ctx.EnsurePageCount(); err != nil {
+		return fmt.Errorf("failed to read PDF for navigation: %w", err)
+	}
+	if len(bookmarks) > 0 {
+		if err := pdfcpu.AddBookmarks(ctx, bookmarks, true); err != nil {
+			return fmt.Errorf("failed to add bookmarks: %w", err)
+		}
+	}
+	if len(links) > 0 {
+		if _, err := pdfcpu.AddAnnotationsMap(ctx, links, false); err != nil {
+			return fmt.Errorf("failed to add links: %w", err)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := api.WriteContext(ctx, &buf); err != nil {
+		return fmt.Errorf("failed to write PDF: %w", err)
+	}
+	return os.WriteFile(path, buf.Bytes(), 0o644)
+}
@@ -8,7 +8,9 @@ import (
 
 	"github.com/aquasecurity/trivy/pkg/types"
 	"github.com/johnfercher/maroto/v2"
+	"github.com/johnfercher/maroto/v2/pkg/components/col"
 	"github.com/johnfercher/maroto/v2/pkg/components/line"
+	"github.com/johnfercher/maroto/v2/pkg/components/page"
 	"github.com/johnfercher/maroto/v2/pkg/components/row"
 	"github.com/johnfercher/maroto/v2/pkg/components/text"
 	"github.com/johnfercher/maroto/v2/pkg/config"
@@ -20,8 +22,29 @@ import (
 	"github.com/johnfercher/maroto/v2/pkg/consts/pagesize"
 	"github.com/johnfercher/maroto/v2/pkg/core"
 	"github.com/johnfercher/maroto/v2/pkg/props"
+	"trivy-plugin-excel/pkg/exporter"
+	"trivy-plugin-excel/pkg/model"
+	"trivy-plugin-excel/pkg/utils"
 )
 
+func init() {
+	exporter.Register(Exporter{})
+}
+
+// Exporter adapts Export to the exporter.Exporter interface.
+type Exporter struct{}
+
+func (Exporter) Name() string      { return "pdf" }
+func (Exporter) Extension() string { return ".pdf" }
+
+func (Exporter) SupportedOptions() []string {
+	return []string{exporter.OptionShowSecretContext, exporter.OptionInventoryAppendix, exporter.OptionDetailsAppendix, exporter.OptionCVSSSource, exporter.OptionOrgName, exporter.OptionOrgLogo}
+}
+
+func (Exporter) Export(report *model.Report, path string, opts exporter.Options) error {
+	return Export(report, path, opts)
+}
+
 var (
 	ColorHeaderOpen = &props.Color{Red: 20, Green: 20, Blue: 20}
 	ColorHeaderText = &props.Color{Red: 40, Green: 40, Blue: 40}
@@ -74,19 +97,9 @@ func getBackgroundColor(severity string) *props.Color {
 	}
 }
 
+// getSeverityWeight ranks a severity for descending sorts, in the order of model.SeverityOrder.
 func getSeverityWeight(severity string) int {
-	switch severity {
-	case "CRITICAL":
-		return 5
-	case "HIGH":
-		return 4
-	case "MEDIUM":
-		return 3
-	case "LOW":
-		return 2
-	default:
-		return 1
-	}
+	return len(model.Severities) + 1 - model.SeverityOrder(severity)
 }
 
 // --- 2. DATA PROCESSING ---
@@ -100,9 +113,9 @@ type SeverityCount struct {
 	Total    int
 }
 
-func countVulnerabilities(report *types.Report) SeverityCount {
+func countVulnerabilities(report *model.Report) SeverityCount {
 	var counts SeverityCount
-	for _, res := range report.Results {
+	for _, res := range report.Results() {
 		for _, v := range res.Vulnerabilities {
 			counts.Total++
 			switch v.Severity {
@@ -122,43 +135,295 @@ func countVulnerabilities(report *types.Report) SeverityCount {
 	return counts
 }
 
-// calculateRowHeight calculates dynamic row height.
-// UPDATED: Now considers 'Fixed Version' length as well, since we increased its column width.
-func calculateRowHeight(pkgNameLen, fixedVerLen, titleLen int) float64 {
-	// Estimations for chars per line based on new column widths:
-	// - PkgName (Width 2): ~20 chars
-	// - Fixed (Width 2): ~20 chars (Critical for long Debian versions)
-	// - Title (Width 3): ~32 chars
-	
-	pkgLines := (pkgNameLen + 18) / 20
-	fixedLines := (fixedVerLen + 18) / 20
-	titleLines := (titleLen + 30) / 32
-
-	maxLines := pkgLines
-	if fixedLines > maxLines {
-		maxLines = fixedLines
+// isPackageResult reports whether a result comes from package scanning, where an empty
+// vulnerability list is worth stating explicitly.
+func isPackageResult(result types.Result) bool {
+	return result.Class == types.ClassOSPkg || result.Class == types.ClassLangPkg
+}
+
+// vulnResults returns the results of an artifact that get a vulnerability table. Config, secret and
+// license results are rendered in their own sections.
+func vulnResults(artifact *model.Artifact) []types.Result {
+	var results []types.Result
+	for _, result := range artifact.Results {
+		if len(result.Vulnerabilities) > 0 || isPackageResult(result) {
+			results = append(results, result)
+		}
+	}
+	return results
+}
+
+// --- 3. TABLES ---
+
+var (
+	// FIXED LAYOUT: ID(2), Sev(1), Pkg(2), Inst(2), Fixed(2), Title(3) -> Total 12
+	// Increased 'Fixed' from 1 to 2 to prevent text overlap.
+	tableHeaders   = []string{"ID", "Severity", "Pkg Name", "Installed", "Fixed", "Title"}
+	tableColWidths = []int{2, 1, 2, 2, 2, 3}
+
+	headerProp = props.Text{
+		Top:    1.5,
+		Style:  fontstyle.Bold,
+		Color:  ColorHeaderText,
+		Align:  align.Center,
+		Family: fontfamily.Arial,
+		Size:   9,
+	}
+	bodyProp = props.Text{
+		Top:    1.5,
+		Size:   8,
+		Family: fontfamily.Arial,
+		Color:  ColorBodyText,
+		Align:  align.Left,
+	}
+)
+
+// addSectionHeader adds a full-width gray section title bar, bookmarked in the PDF outline.
+func addSectionHeader(m core.Maroto, title string) {
+	m.AddRows(outlineSectionRow(title))
+}
+
+// outlineSectionRow builds a section title bar bookmarked in the PDF outline.
+func outlineSectionRow(title string) core.Row {
+	return withAnchor(sectionHeaderRow(title), title, title, 0)
+}
+
+// sectionHeaderRow builds a full-width gray section title bar.
+func sectionHeaderRow(title string) core.Row {
+	header := row.New(8)
+	header.WithStyle(&props.Cell{
+		BackgroundColor: ColorBgHeader,
+		BorderType:      border.Full,
+		BorderColor:     ColorLightGray,
+	})
+	header.Add(
+		text.NewCol(12, title, props.Text{
+			Top:    1.5,
+			Style:  fontstyle.Bold,
+			Align:  align.Left,
+			Family: fontfamily.Arial,
+			Color:  ColorHeaderText,
+			Size:   9,
+		}),
+	)
+	return header
+}
+
+// tableHeaderRow builds a gray table header row.
+func tableHeaderRow(headers []string, widths []int) core.Row {
+	headerRow := row.New(8)
+	headerRow.WithStyle(&props.Cell{BackgroundColor: ColorBgHeader})
+	for i, h := range headers {
+		headerRow.Add(text.NewCol(widths[i], h, headerProp))
+	}
+	return headerRow
+}
+
+// sortBySeverity returns a copy of the vulnerabilities ordered by severity, then package name.
+// Sorting a copy keeps concurrent exporters sharing the report from seeing it reordered.
+func sortBySeverity(vulns []types.DetectedVulnerability) []types.DetectedVulnerability {
+	sorted := append([]types.DetectedVulnerability(nil), vulns...)
+	sort.Slice(sorted, func(i, j int) bool {
+		v1 := sorted[i]
+		v2 := sorted[j]
+		w1 := getSeverityWeight(v1.Severity)
+		w2 := getSeverityWeight(v2.Severity)
+		if w1 != w2 {
+			return w1 > w2
+		}
+		return v1.PkgName < v2.PkgName
+	})
+	return sorted
+}
+
+// addVulnTable renders the vulnerability table header and one row per finding.
+// The preferred CVSS v3 score is shown under the severity and its vector under the title.
+// titleNote, when non-nil, may return extra text appended to the title cell.
+// The ID cells of vulnerabilities listed in details link to their appendix entry.
+func addVulnTable(m core.Maroto, vulns []types.DetectedVulnerability, cvssSources []string, details map[string]bool, titleNote func(types.DetectedVulnerability) string) {
+	m.AddRows(tableHeaderRow(tableHeaders, tableColWidths))
+
+	if len(vulns) == 0 {
+		m.AddRows(
+			text.NewRow(8, "No vulnerabilities found.", props.Text{
+				Style:  fontstyle.Italic,
+				Align:  align.Center,
+				Family: fontfamily.Arial,
+				Color:  ColorBodyText,
+				Size:   8,
+			}),
+		)
+		return
+	}
+
+	for _, vuln := range sortBySeverity(vulns) {
+		displayTitle := strings.ReplaceAll(vuln.Title, "\n", " ")
+		if titleNote != nil {
+			if note := titleNote(vuln); note != "" {
+				displayTitle += " " + note
+			}
+		}
+		fixedVer := vuln.FixedVersion
+		if fixedVer == "" {
+			fixedVer = "-"
+		}
+
+		cvssSource, score, vector, hasCVSS := utils.PreferredCVSS(vuln, cvssSources)
+		cvssProp := bodyProp
+		cvssProp.Size = 6.5
+		cvssProp.Color = ColorGrayText
+
+		// The row fits the tallest cell; the CVSS vector wraps below the title
+		titleWidth := gridWidth(tableColWidths[5]) - bodyProp.Left - bodyProp.Right
+		titleLines := wrapText(displayTitle, titleWidth, bodyProp)
+		var vectorLines []string
+		if hasCVSS {
+			vectorLines = wrapText(vector, titleWidth, cvssProp)
+		}
+		rowHeight := fitRowHeight(
+			[]string{vuln.VulnerabilityID, vuln.Severity, vuln.PkgName, vuln.InstalledVersion, fixedVer},
+			tableColWidths[:5], bodyProp)
+		if h := bodyProp.Top + float64(len(titleLines))*lineHeight(bodyProp) + float64(len(vectorLines))*lineHeight(cvssProp) + cellPadding; h > rowHeight {
+			rowHeight = h
+		}
+		if h := bodyProp.Top + lineHeight(bodyProp) + lineHeight(cvssProp) + cellPadding; hasCVSS && h > rowHeight {
+			rowHeight = h
+		}
+
+		r := row.New(rowHeight)
+
+		bgColor := getBackgroundColor(vuln.Severity)
+		r.WithStyle(&props.Cell{BackgroundColor: bgColor})
+
+		sevProp := bodyProp
+		sevProp.Style = fontstyle.Bold
+		sevProp.Color = getSeverityColor(vuln.Severity)
+		sevProp.Align = align.Center
+
+		sevCol := wrapCol(tableColWidths[1], vuln.Severity, sevProp)
+		titleCol := col.New(tableColWidths[5])
+		addLines(titleCol, titleLines, bodyProp)
+		if hasCVSS {
+			// The score and the last line of the vector sit at the bottom of the row
+			cvssProp.Top = rowHeight - cellPadding - float64(len(vectorLines))*lineHeight(cvssProp)
+			addLines(titleCol, vectorLines, cvssProp)
+
+			scoreProp := cvssProp
+			scoreProp.Top = rowHeight - cellPadding - lineHeight(cvssProp)
+			scoreProp.Align = align.Center
+			sevCol.Add(text.New(fmt.Sprintf("%.1f (%s)", score, cvssSource), scoreProp))
+		}
+
+		idCol := wrapCol(tableColWidths[0], vuln.VulnerabilityID, bodyProp)
+		if details[vuln.VulnerabilityID] {
+			idProp := bodyProp
+			idProp.Color = &props.BlueColor
+			idCol = linkCol(wrapCol(tableColWidths[0], vuln.VulnerabilityID, idProp), detailID(vuln.VulnerabilityID))
+		}
+
+		r.Add(
+			idCol,
+			sevCol,
+			wrapCol(tableColWidths[2], vuln.PkgName, bodyProp),
+			wrapCol(tableColWidths[3], vuln.InstalledVersion, bodyProp),
+			wrapCol(tableColWidths[4], fixedVer, bodyProp),
+			titleCol,
+		)
+		m.AddRows(r)
+	}
+}
+
+// addWhatChanged renders the diff section: counts per change category,
+// then the introduced and resolved findings. Persisting findings are listed in the regular tables.
+func addWhatChanged(m core.Maroto, diff *model.Diff, cvssSources []string, details map[string]bool) {
+	addSectionHeader(m, fmt.Sprintf("WHAT CHANGED (%s -> %s)", diff.OldArtifact, diff.NewArtifact))
+
+	countsRow := row.New(12)
+	countsRow.WithStyle(&props.Cell{
+		BorderType:  border.Full,
+		BorderColor: ColorLightGray,
+	})
+	changeColors := map[model.Change]*props.Color{
+		model.ChangeIntroduced: ColorSevCritical,
+		model.ChangeResolved:   &props.Color{Red: 40, Green: 150, Blue: 70},
+		model.ChangePersisting: ColorGrayText,
 	}
-	if titleLines > maxLines {
-		maxLines = titleLines
+	for _, change := range model.Changes {
+		countsRow.Add(text.NewCol(4, fmt.Sprintf("%d %s", len(diff.ByChange(change)), change), props.Text{
+			Top: 3, Size: 10, Style: fontstyle.Bold, Align: align.Center, Family: fontfamily.Arial, Color: changeColors[change],
+		}))
 	}
-	
-	if maxLines < 1 {
-		maxLines = 1
+	m.AddRows(countsRow)
+
+	for _, change := range []model.Change{model.ChangeIntroduced, model.ChangeResolved} {
+		m.AddRows(
+			text.NewRow(12, string(change), props.Text{
+				Top:    3,
+				Style:  fontstyle.Bold,
+				Size:   10,
+				Family: fontfamily.Arial,
+				Color:  ColorDarkGray,
+				Align:  align.Left,
+			}),
+		)
+
+		var vulns []types.DetectedVulnerability
+		targets := make(map[string]string)
+		for _, finding := range diff.ByChange(change) {
+			vulns = append(vulns, finding.Vulnerability)
+			targets[model.VulnKey(finding.Vulnerability)] = finding.Target
+		}
+		addVulnTable(m, vulns, cvssSources, details, func(vuln types.DetectedVulnerability) string {
+			return "(" + targets[model.VulnKey(vuln)] + ")"
+		})
 	}
-	
-	// Base height 6 + 4 per extra line
-	return 6.0 + (float64(maxLines) * 4.0)
+	m.AddRows(row.New(10))
 }
 
-// --- 3. MAIN EXPORT ---
+// --- 4. MAIN EXPORT ---
+
+func Export(report *model.Report, path string, opts exporter.Options) error {
+	if err := checkLogo(opts.OrgLogo); err != nil {
+		return err
+	}
+	generatedAt := time.Now()
+
+	// Lay the document out once to learn the page of every target, then build it again with the page
+	// numbers in the table of contents. The contents take up the same space in both passes.
+	layout, err := build(report, opts, generatedAt, nil)
+	if err != nil {
+		return err
+	}
+	anchors := layoutAnchors(layout)
+
+	m, err := build(report, opts, generatedAt, anchorPages(anchors))
+	if err != nil {
+		return err
+	}
+	document, err := m.Generate()
+	if err != nil {
+		return err
+	}
+	return saveWithNavigation(document.GetBytes(), path, anchors, m.GetCurrentConfig().Dimensions.Height)
+}
 
-func Export(report *types.Report, path string) error {
+// build adds every page of the report to a new document. pages holds the page of each anchor
+// for the table of contents, nil while the document is being laid out.
+func build(report *model.Report, opts exporter.Options, generatedAt time.Time, pages map[string]int) (core.Maroto, error) {
 	cfg := config.NewBuilder().
 		WithOrientation(orientation.Horizontal).
 		WithPageSize(pagesize.A4).
 		WithLeftMargin(10).
 		WithTopMargin(10).
 		WithRightMargin(10).
+		// Footer: maroto only knows the page total once every page is added
+		WithPageNumber(props.PageNumber{
+			Pattern: "Generated by Trivy Plugin | " + generatedAt.Format("2006-01-02 15:04") + " | Page {current} of {total}",
+			Place:   props.RightBottom,
+			Size:    7,
+			Style:   fontstyle.Italic,
+			Color:   ColorLightGray,
+		}).
 		Build()
 
 	m := maroto.New(cfg)
@@ -175,30 +440,33 @@ func Export(report *types.Report, path string) error {
 		}),
 	)
 
+	// The optional appendix lists every vulnerability once; the tables link their IDs to it
+	var details []*vulnDetail
+	var linked map[string]bool
+	if opts.DetailsAppendix {
+		details = collectVulnDetails(report)
+		linked = detailIDs(details)
+	}
+
+	// --- Cover page: what was scanned, when, and for whom ---
+	m.AddPages(page.New().Add(coverRows(report, opts, generatedAt)...))
+
+	// --- Table of contents: every target with its findings and page ---
+	if toc := tocRows(report, pages); len(toc) > 0 {
+		m.AddPages(page.New().Add(toc...))
+	}
+
 	// --- Dashboard Data ---
 	counts := countVulnerabilities(report)
-	currentTime := time.Now().Format("2006-01-02 15:04")
+	scannedAt := "N/A"
+	if t := scanTime(report); t != nil {
+		scannedAt = t.UTC().Format("2006-01-02 15:04")
+	}
 
-	m.AddRows(row.New(5))
+	m.AddPages(page.New().Add(row.New(5)))
 
 	// --- SUMMARY SECTION ---
-	summaryHeader := row.New(8)
-	summaryHeader.WithStyle(&props.Cell{
-		BackgroundColor: ColorBgHeader,
-		BorderType:      border.Full,
-		BorderColor:     ColorLightGray,
-	})
-	summaryHeader.Add(
-		text.NewCol(12, "SCAN SUMMARY", props.Text{
-			Top:    1.5,
-			Style:  fontstyle.Bold,
-			Align:  align.Left,
-			Family: fontfamily.Arial,
-			Color:  ColorHeaderText,
-			Size:   9,
-		}),
-	)
-	m.AddRows(summaryHeader)
+	addSectionHeader(m, "SCAN SUMMARY")
 
 	statsRow := row.New(16)
 	statsRow.WithStyle(&props.Cell{
@@ -206,15 +474,15 @@ func Export(report *types.Report, path string) error {
 		BorderColor: ColorLightGray,
 	})
 
-	statsRow.Add(
-		text.NewCol(2, fmt.Sprintf("Date: %s \nStatus: Completed", currentTime), props.Text{
-			Top:    3,
-			Size:   9,
-			Family: fontfamily.Arial,
-			Color:  ColorBodyText,
-			Align:  align.Left,
-		}),
-	)
+	scanCol := col.New(2)
+	addLines(scanCol, []string{"Scanned: " + scannedAt, "Status: Completed"}, props.Text{
+		Top:    3,
+		Size:   9,
+		Family: fontfamily.Arial,
+		Color:  ColorBodyText,
+		Align:  align.Left,
+	})
+	statsRow.Add(scanCol)
 
 	addStatCol := func(label string, count int, c *props.Color) core.Col {
 		return text.NewCol(2, fmt.Sprintf("%d %s", count, label), props.Text{
@@ -236,129 +504,97 @@ func Export(report *types.Report, path string) error {
 	}
 
 	m.AddRows(statsRow)
-	m.AddRows(row.New(10))
 
-	// --- Table Configuration ---
-	// FIXED LAYOUT: ID(2), Sev(1), Pkg(2), Inst(2), Fixed(2), Title(3) -> Total 12
-	// Increased 'Fixed' from 1 to 2 to prevent text overlap.
-	headers := []string{"ID", "Severity", "Pkg Name", "Installed", "Fixed", "Title"}
-	colWidths := []int{2, 1, 2, 2, 2, 3}
-
-	headerProp := props.Text{
-		Top:    1.5,
-		Style:  fontstyle.Bold,
-		Color:  ColorHeaderText,
-		Align:  align.Center,
-		Family: fontfamily.Arial,
-		Size:   9,
-	}
-	bodyProp := props.Text{
-		Top:    1.5,
-		Size:   8,
-		Family: fontfamily.Arial,
-		Color:  ColorBodyText,
-		Align:  align.Left,
+	// Record the active filters so readers know the counts are partial
+	if report.Filter.Active() {
+		m.AddRows(text.NewRow(6, "Filters: "+strings.Join(report.Filter.Describe(), "; "), props.Text{
+			Top:    1.5,
+			Size:   7,
+			Style:  fontstyle.Italic,
+			Family: fontfamily.Arial,
+			Color:  ColorGrayText,
+			Align:  align.Left,
+		}))
 	}
 
-	// Footer
-	m.RegisterFooter(
-		row.New(5).Add(
-			text.NewCol(12, "Generated by Trivy Plugin | "+currentTime, props.Text{
-				Align: align.Right,
-				Size:  7,
-				Color: ColorLightGray,
-				Style: fontstyle.Italic,
-			}),
-		),
-	)
+	// Charts: severity distribution and the most vulnerable targets
+	charts, err := chartRows(report)
+	if err != nil {
+		return nil, err
+	}
+	m.AddRows(charts...)
+	m.AddRows(row.New(10))
 
-	// --- Result Iteration ---
-	for _, result := range report.Results {
-		sort.Slice(result.Vulnerabilities, func(i, j int) bool {
-			v1 := result.Vulnerabilities[i]
-			v2 := result.Vulnerabilities[j]
-			w1 := getSeverityWeight(v1.Severity)
-			w2 := getSeverityWeight(v2.Severity)
-			if w1 != w2 {
-				return w1 > w2
-			}
-			return v1.PkgName < v2.PkgName
-		})
+	// --- WHAT CHANGED SECTION (diff reports only) ---
+	if report.Diff != nil {
+		addWhatChanged(m, report.Diff, opts.CVSSSources, linked)
+	}
 
-		fullTargetInfo := fmt.Sprintf("Target: %s (%s)", result.Target, result.Class)
-		
-		m.AddRows(
-			row.New(15).Add(
-				text.NewCol(12, fullTargetInfo, props.Text{
-					Top:    2,
+	// --- Result Iteration (grouped by artifact) ---
+	targets := 0
+	for ai := range report.Artifacts {
+		artifact := &report.Artifacts[ai]
+
+		// Merged reports get a heading per artifact so findings stay grouped by image
+		targetLevel := 0
+		if report.Merged() {
+			heading := fmt.Sprintf("Artifact: %s (%s)", artifact.Name, utils.SetArtifactType(artifact.Type))
+			m.AddRows(withAnchor(
+				text.NewRow(12, heading, props.Text{
+					Top:    3,
 					Style:  fontstyle.Bold,
-					Size:   10,
+					Size:   12,
 					Family: fontfamily.Arial,
-					Color:  ColorDarkGray,
+					Color:  ColorHeaderOpen,
 					Align:  align.Left,
 				}),
-			),
-		)
-
-		headerRow := row.New(8)
-		headerRow.WithStyle(&props.Cell{BackgroundColor: ColorBgHeader})
-		for i, h := range headers {
-			headerRow.Add(text.NewCol(colWidths[i], h, headerProp))
+				fmt.Sprintf("artifact-%d", ai+1), heading, 0,
+			))
+			targetLevel = 1
 		}
-		m.AddRows(headerRow)
 
-		if len(result.Vulnerabilities) == 0 {
-			m.AddRows(
-				text.NewRow(8, "No vulnerabilities found.", props.Text{
-					Style:  fontstyle.Italic,
-					Align:  align.Center,
-					Family: fontfamily.Arial,
-					Color:  ColorBodyText,
-					Size:   8,
-				}),
-			)
-		} else {
-			for _, vuln := range result.Vulnerabilities {
-				displayTitle := strings.ReplaceAll(vuln.Title, "\n", " ")
-				fixedVer := vuln.FixedVersion
-				if fixedVer == "" {
-					fixedVer = "-"
+		for _, result := range vulnResults(artifact) {
+			targets++
+			fullTargetInfo := fmt.Sprintf("Target: %s (%s)", result.Target, result.Class)
+
+			m.AddRows(withAnchor(
+				row.New(15).Add(
+					text.NewCol(12, fullTargetInfo, props.Text{
+						Top:    2,
+						Style:  fontstyle.Bold,
+						Size:   10,
+						Family: fontfamily.Arial,
+						Color:  ColorDarkGray,
+						Align:  align.Left,
+					}),
+				),
+				targetID(targets), fmt.Sprintf("%s (%s)", result.Target, result.Class), targetLevel,
+			))
+
+			// Deduplicated findings note how many artifacts share them
+			addVulnTable(m, result.Vulnerabilities, opts.CVSSSources, linked, func(vuln types.DetectedVulnerability) string {
+				if n := len(report.ArtifactNames(artifact, vuln)); n > 1 {
+					return fmt.Sprintf("[found in %d artifacts]", n)
 				}
+				return ""
+			})
 
-				// Calculate dynamic row height, including Fixed Version length
-				rowHeight := calculateRowHeight(len(vuln.PkgName), len(fixedVer), len(displayTitle))
-
-				r := row.New(rowHeight)
-				
-				bgColor := getBackgroundColor(vuln.Severity)
-				r.WithStyle(&props.Cell{BackgroundColor: bgColor})
-
-				sevProp := bodyProp
-				sevProp.Style = fontstyle.Bold
-				sevProp.Color = getSeverityColor(vuln.Severity)
-				sevProp.Align = align.Center
-
-				r.Add(
-					text.NewCol(colWidths[0], vuln.VulnerabilityID, bodyProp),
-					text.NewCol(colWidths[1], vuln.Severity, sevProp),
-					text.NewCol(colWidths[2], vuln.PkgName, bodyProp),
-					text.NewCol(colWidths[3], vuln.InstalledVersion, bodyProp),
-					text.NewCol(colWidths[4], fixedVer, bodyProp), // Width 2 now
-					text.NewCol(colWidths[5], displayTitle, bodyProp), // Width 3 now
-				)
-				m.AddRows(r)
-			}
+			m.AddRows(
+				line.NewRow(1.0, props.Line{Color: &props.Color{Red: 200, Green: 200, Blue: 200}}),
+				row.New(8),
+			)
 		}
-		
-		m.AddRows(
-			line.NewRow(1.0, props.Line{Color: &props.Color{Red: 200, Green: 200, Blue: 200}}),
-			row.New(8),
-		)
 	}
 
-	document, err := m.Generate()
-	if err != nil {
-		return err
+	// --- Other finding kinds ---
+	addMisconfigSection(m, report)
+	addSecretSection(m, report, opts.ShowSecretContext)
+	addLicensePage(m, report)
+	addSuppressedAppendix(m, report)
+	addDetailsAppendix(m, details)
+	if opts.InventoryAppendix {
+		addInventoryAppendix(m, report)
 	}
-	return document.Save(path)
+
+	return m, nil
 }
@@ -0,0 +1,92 @@
+package pdf
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/aquasecurity/trivy/pkg/types"
+	"github.com/johnfercher/maroto/v2/pkg/components/row"
+	"github.com/johnfercher/maroto/v2/pkg/components/text"
+	"github.com/johnfercher/maroto/v2/pkg/consts/align"
+	"github.com/johnfercher/maroto/v2/pkg/consts/fontfamily"
+	"github.com/johnfercher/maroto/v2/pkg/consts/fontstyle"
+	"github.com/johnfercher/maroto/v2/pkg/core"
+	"github.com/johnfercher/maroto/v2/pkg/props"
+	"trivy-plugin-excel/pkg/model"
+	"trivy-plugin-excel/pkg/utils"
+)
+
+var (
+	// LAYOUT: Rule(2), Sev(1), Category(1), Title(3), Lines(1), Match(4) -> Total 12
+	secretHeaders   = []string{"Rule ID", "Severity", "Category", "Title", "Lines", "Match"}
+	secretColWidths = []int{2, 1, 1, 3, 1, 4}
+)
+
+// addSecretSection renders the secret findings of every target that has any.
+// Matches are masked unless showContext is set; code snippets are left to the Excel and CSV exports.
+func addSecretSection(m core.Maroto, report *model.Report, showContext bool) {
+	started := false
+	for _, artifact := range report.Artifacts {
+		for _, result := range artifact.Results {
+			if len(result.Secrets) == 0 {
+				continue
+			}
+			if !started {
+				addSectionHeader(m, "SECRETS")
+				started = true
+			}
+
+			m.AddRows(
+				text.NewRow(12, fmt.Sprintf("Target: %s", result.Target), props.Text{
+					Top:    3,
+					Style:  fontstyle.Bold,
+					Size:   10,
+					Family: fontfamily.Arial,
+					Color:  ColorDarkGray,
+					Align:  align.Left,
+				}),
+			)
+			addSecretTable(m, result.Secrets, showContext)
+		}
+	}
+}
+
+// addSecretTable renders the secret table header and one row per finding, most severe first.
+func addSecretTable(m core.Maroto, secrets []types.DetectedSecret, showContext bool) {
+	m.AddRows(tableHeaderRow(secretHeaders, secretColWidths))
+
+	sorted := append([]types.DetectedSecret(nil), secrets...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return getSeverityWeight(sorted[i].Severity) > getSeverityWeight(sorted[j].Severity)
+	})
+
+	for _, secret := range sorted {
+		match, _ := utils.SecretContext(secret, showContext)
+		cells := []string{
+			secret.RuleID,
+			secret.Severity,
+			string(secret.Category),
+			secret.Title,
+			utils.FormatLineRange(secret.StartLine, secret.EndLine),
+			match,
+		}
+
+		r := row.New(fitRowHeight(cells, secretColWidths, bodyProp))
+		r.WithStyle(&props.Cell{BackgroundColor: getBackgroundColor(secret.Severity)})
+
+		sevProp := bodyProp
+		sevProp.Style = fontstyle.Bold
+		sevProp.Color = getSeverityColor(secret.Severity)
+		sevProp.Align = align.Center
+
+		for i, cell := range cells {
+			prop := bodyProp
+			if i == 1 {
+				prop = sevProp
+			}
+			r.Add(wrapCol(secretColWidths[i], cell, prop))
+		}
+		m.AddRows(r)
+	}
+	m.AddRows(row.New(6))
+}
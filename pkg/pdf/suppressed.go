@@ -0,0 +1,63 @@
+package pdf
+
+import (
+	"github.com/johnfercher/maroto/v2/pkg/components/page"
+	"github.com/johnfercher/maroto/v2/pkg/components/row"
+	"github.com/johnfercher/maroto/v2/pkg/consts/align"
+	"github.com/johnfercher/maroto/v2/pkg/consts/fontstyle"
+	"github.com/johnfercher/maroto/v2/pkg/core"
+	"github.com/johnfercher/maroto/v2/pkg/props"
+	"trivy-plugin-excel/pkg/model"
+)
+
+var (
+	// LAYOUT: Sev(1), ID(2), Package(2), Status(1), Justification(4), Source(2) -> Total 12
+	suppressedHeaders   = []string{"Severity", "ID", "Package / Target", "Status", "Justification", "Source / Expires"}
+	suppressedColWidths = []int{1, 2, 2, 1, 4, 2}
+)
+
+// addSuppressedAppendix lists the findings accepted by an ignore file or VEX statement on a new page.
+// Nothing is added when nothing was suppressed.
+func addSuppressedAppendix(m core.Maroto, report *model.Report) {
+	if !report.HasSuppressed() {
+		return
+	}
+
+	rows := []core.Row{outlineSectionRow("APPENDIX: SUPPRESSED FINDINGS"), row.New(4)}
+	rows = append(rows, tableHeaderRow(suppressedHeaders, suppressedColWidths))
+
+	for _, s := range report.Suppressed {
+		subject := s.Target
+		if s.Package != "" {
+			subject = s.Package + " (" + s.Target + ")"
+		}
+		statement := s.Statement
+		if statement == "" {
+			statement = "-"
+		}
+		source := s.Source
+		if expires := s.Expires(); expires != "" {
+			source += ", expires " + expires
+		}
+		cells := []string{s.Severity, s.ID, subject, string(s.Status), statement, source}
+
+		r := row.New(fitRowHeight(cells, suppressedColWidths, bodyProp))
+		r.WithStyle(&props.Cell{BackgroundColor: getBackgroundColor(s.Severity)})
+
+		sevProp := bodyProp
+		sevProp.Style = fontstyle.Bold
+		sevProp.Color = getSeverityColor(s.Severity)
+		sevProp.Align = align.Center
+
+		for i, cell := range cells {
+			prop := bodyProp
+			if i == 0 {
+				prop = sevProp
+			}
+			r.Add(wrapCol(suppressedColWidths[i], cell, prop))
+		}
+		rows = append(rows, r)
+	}
+
+	m.AddPages(page.New().Add(rows...))
+}
@@ -0,0 +1,187 @@
+package triage
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/aquasecurity/trivy/pkg/types"
+	"github.com/openvex/go-vex/pkg/vex"
+	"gopkg.in/yaml.v3"
+	"trivy-plugin-excel/pkg/model"
+)
+
+// Decisions a reviewer can record for a vulnerability
+const (
+	Accept        = "Accept"
+	Fix           = "Fix"
+	FalsePositive = "False Positive"
+	Defer         = "Defer"
+)
+
+// Decisions lists the valid decisions in the order the workbook offers them.
+var Decisions = []string{Accept, Fix, FalsePositive, Defer}
+
+// Entry is a triaged vulnerability read back from a workbook.
+type Entry struct {
+	Artifact         string
+	Target           string
+	VulnerabilityID  string
+	Package          string
+	InstalledVersion string
+	Path             string
+	PURL             string
+
+	Owner    string
+	Decision string
+	// DueDate is when an accepted or deferred vulnerability is due for review (zero when unset)
+	DueDate time.Time
+	Comment string
+}
+
+// Key identifies the vulnerability across scans, like model.VulnKey does for a detected vulnerability.
+// The target is left out, as it names the scanned image tag for OS packages.
+func (e Entry) Key() string {
+	return model.VulnKey(types.DetectedVulnerability{
+		VulnerabilityID:  e.VulnerabilityID,
+		PkgName:          e.Package,
+		InstalledVersion: e.InstalledVersion,
+		PkgPath:          e.Path,
+	})
+}
+
+// Triaged reports whether a reviewer filled in any of the triage columns.
+func (e Entry) Triaged() bool {
+	return e.Owner != "" || e.Decision != "" || !e.DueDate.IsZero() || e.Comment != ""
+}
+
+// ValidDecision reports whether d is one of Decisions.
+func ValidDecision(d string) bool {
+	for _, decision := range Decisions {
+		if d == decision {
+			return true
+		}
+	}
+	return false
+}
+
+// Suppresses reports whether the decision takes the vulnerability out of future reports.
+// Vulnerabilities to fix stay visible; accepted and deferred ones return after their due date.
+func (e Entry) Suppresses() bool {
+	return e.Decision == Accept || e.Decision == FalsePositive || e.Decision == Defer
+}
+
+// statement explains the decision in the words of the reviewer.
+func (e Entry) statement() string {
+	s := e.Decision
+	if e.Owner != "" {
+		s += " by " + e.Owner
+	}
+	if e.Comment != "" {
+		s += ": " + e.Comment
+	}
+	return s
+}
+
+// ignoreFinding is an entry of the vulnerabilities list of .trivyignore.yaml.
+type ignoreFinding struct {
+	ID        string    `yaml:"id"`
+	Paths     []string  `yaml:"paths,omitempty"`
+	PURLs     []string  `yaml:"purls,omitempty"`
+	ExpiredAt time.Time `yaml:"expired_at,omitempty"`
+	Statement string    `yaml:"statement,omitempty"`
+}
+
+// WriteIgnoreFile writes the suppressing decisions as an ignore file Trivy reads with --ignorefile.
+// The YAML form (.trivyignore.yaml) is scoped to the package, or its path when there is no package URL;
+// the plain form only lists IDs, with the decision as a comment. Due dates become expiry dates.
+// It returns the number of entries written, which leaves out the vulnerabilities to fix.
+func WriteIgnoreFile(w io.Writer, entries []Entry, asYAML bool) (int, error) {
+	if asYAML {
+		var findings []ignoreFinding
+		for _, e := range entries {
+			if !e.Suppresses() {
+				continue
+			}
+			finding := ignoreFinding{ID: e.VulnerabilityID, ExpiredAt: e.DueDate, Statement: e.statement()}
+			switch {
+			case e.PURL != "":
+				finding.PURLs = []string{e.PURL}
+			case e.Path != "":
+				finding.Paths = []string{e.Path}
+			}
+			findings = append(findings, finding)
+		}
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		if err := enc.Encode(map[string][]ignoreFinding{"vulnerabilities": findings}); err != nil {
+			return 0, fmt.Errorf("failed to write ignore file: %w", err)
+		}
+		return len(findings), enc.Close()
+	}
+
+	written := 0
+	for _, e := range entries {
+		if !e.Suppresses() {
+			continue
+		}
+		line := e.VulnerabilityID
+		if !e.DueDate.IsZero() {
+			line += " exp:" + e.DueDate.Format("2006-01-02")
+		}
+		comment := strings.Join(strings.Fields(e.statement()), " ")
+		if _, err := fmt.Fprintf(w, "# %s (%s)\n%s\n", comment, e.Package, line); err != nil {
+			return written, fmt.Errorf("failed to write ignore file: %w", err)
+		}
+		written++
+	}
+	return written, nil
+}
+
+// WriteVEX writes every decision as an OpenVEX statement about the package URL of the vulnerable package.
+// Only false positives are not_affected, which Trivy --vex suppresses; accepted, deferred and
+// to-be-fixed vulnerabilities remain affected, with the decision as the action statement.
+// Trivy matches VEX products by package URL, so entries without one are left out and returned as skipped.
+// written is the number of statements in the document.
+func WriteVEX(w io.Writer, entries []Entry, author string) (written int, skipped []Entry, err error) {
+	doc := vex.New()
+	if author != "" {
+		doc.Author = author
+	}
+	doc.Tooling = "trivy report import-triage"
+
+	for _, e := range entries {
+		if e.PURL == "" {
+			skipped = append(skipped, e)
+			continue
+		}
+		stmt := vex.Statement{
+			Vulnerability: vex.Vulnerability{Name: vex.VulnerabilityID(e.VulnerabilityID)},
+			Products:      []vex.Product{{Component: vex.Component{ID: e.PURL}}},
+		}
+		if e.Owner != "" {
+			stmt.StatusNotes = "Owner: " + e.Owner
+		}
+		switch e.Decision {
+		case FalsePositive:
+			stmt.Status = vex.StatusNotAffected
+			stmt.ImpactStatement = e.statement()
+		default:
+			stmt.Status = vex.StatusAffected
+			stmt.ActionStatement = e.statement()
+			if !e.DueDate.IsZero() {
+				stmt.ActionStatement += " (due " + e.DueDate.Format("2006-01-02") + ")"
+			}
+		}
+		if err := stmt.Validate(); err != nil {
+			return 0, skipped, fmt.Errorf("invalid VEX statement for %s: %w", e.VulnerabilityID, err)
+		}
+		doc.Statements = append(doc.Statements, stmt)
+	}
+
+	if _, err := doc.GenerateCanonicalID(); err != nil {
+		return 0, skipped, fmt.Errorf("failed to identify VEX document: %w", err)
+	}
+	return len(doc.Statements), skipped, doc.ToJSON(w)
+}
@@ -2,11 +2,15 @@ package utils
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"sort"
 	"strconv"
+	"strings"
 	"time"
+	"unicode"
 
 	"github.com/aquasecurity/trivy/pkg/fanal/artifact"
 	"github.com/aquasecurity/trivy/pkg/log"
@@ -45,6 +49,123 @@ func FormatTime(t *time.Time) string {
 	return t.UTC().Format("Jan 02, 2006 15:04:05 UTC")
 }
 
+// DefaultCVSSSources is the vendor preference used to pick a CVSS score when none is configured
+var DefaultCVSSSources = []string{"nvd", "redhat", "ghsa"}
+
+// PreferredCVSS picks the CVSS v3 score and vector to display for a vulnerability.
+// It tries the given sources in order, then the vulnerability's severity source, then any
+// remaining vendor alphabetically. ok is false when no vendor provides a v3 score.
+func PreferredCVSS(vuln types.DetectedVulnerability, sources []string) (source string, score float64, vector string, ok bool) {
+	candidates := append([]string(nil), sources...)
+	candidates = append(candidates, string(vuln.SeveritySource))
+
+	var vendors []string
+	for id := range vuln.CVSS {
+		vendors = append(vendors, string(id))
+	}
+	sort.Strings(vendors)
+	candidates = append(candidates, vendors...)
+
+	for _, candidate := range candidates {
+		for id, cvss := range vuln.CVSS {
+			if strings.EqualFold(string(id), candidate) && cvss.V3Score > 0 {
+				return string(id), cvss.V3Score, cvss.V3Vector, true
+			}
+		}
+	}
+	return "", 0, "", false
+}
+
+// FormatLineRange renders a start/end line pair as "12-15" (or "12" for a single line)
+func FormatLineRange(start, end int) string {
+	if start <= 0 {
+		return ""
+	}
+	if end <= start {
+		return strconv.Itoa(start)
+	}
+	return fmt.Sprintf("%d-%d", start, end)
+}
+
+// MaskSecret hides secret material while keeping a leading "KEY=" or "key:" assignment,
+// so readers can tell what leaked without seeing the value. The mask has a fixed length
+// to avoid disclosing the secret's size. Text before the separator is only kept when it reads
+// as a key name, so a token such as "AKIA...:secret" or "ghp_...:x-oauth-basic" is masked whole.
+func MaskSecret(s string) string {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return ""
+	}
+	prefix := ""
+	if i := strings.IndexAny(s, "=:"); i > 0 && i <= 64 && isKeyName(strings.TrimSpace(s[:i]), s[i+1:]) {
+		prefix = s[:i+1]
+	}
+	return prefix + "********"
+}
+
+// variableName matches keys written like a variable or property name, e.g. DB_PASSWORD or spring.datasource.password.
+var variableName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*([.-][A-Za-z_][A-Za-z0-9_]*)*$`)
+
+// isKeyName reports whether key, followed by rest after its separator, names the value instead of being part of it:
+// the value is set off by a space or quote, or the key is written like a variable name. Keys that look like
+// tokens never qualify.
+func isKeyName(key, rest string) bool {
+	if !isIdentifier(key) || looksLikeToken(key) {
+		return false
+	}
+	if rest != "" && strings.ContainsAny(rest[:1], " \t\"'`") {
+		return true
+	}
+	return variableName.MatchString(key)
+}
+
+// looksLikeToken reports whether any part of key is a long run of characters, or letters mixed with digits,
+// as in access key IDs and API tokens rather than in the words of a key name.
+func looksLikeToken(key string) bool {
+	parts := strings.FieldsFunc(key, func(r rune) bool { return r == '_' || r == '-' || r == '.' })
+	for _, part := range parts {
+		if len(part) >= 24 {
+			return true
+		}
+		if len(part) >= 12 && strings.IndexFunc(part, unicode.IsDigit) >= 0 && strings.IndexFunc(part, unicode.IsLetter) >= 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// SecretContext returns the match and code snippet of a secret finding for display.
+// Unless show is set, both are masked.
+func SecretContext(secret types.DetectedSecret, show bool) (match, code string) {
+	var lines []string
+	for _, line := range secret.Code.Lines {
+		content := line.Content
+		if !show {
+			// Context lines may hold secrets too (e.g. the rest of a private key), so mask them all
+			content = MaskSecret(content)
+		}
+		lines = append(lines, fmt.Sprintf("%d: %s", line.Number, content))
+	}
+
+	match = secret.Match
+	if !show {
+		match = MaskSecret(match)
+	}
+	return match, strings.Join(lines, "\n")
+}
+
+func isIdentifier(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if !(r == '_' || r == '-' || r == '.' || unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			return false
+		}
+	}
+	return true
+}
+
 // ReadJSONFromFile reads and parses a Trivy JSON report from a local file
 func ReadJSONFromFile(filename string) (*types.Report, error) {
 	if filepath.Ext(filename) != ".json" {
@@ -64,6 +185,56 @@ func ReadJSONFromFile(filename string) (*types.Report, error) {
 	return &report, nil
 }
 
+// ExpandInputs resolves files, directories and glob patterns into a sorted list of unique paths.
+// Directories contribute the JSON files they contain (non-recursive).
+func ExpandInputs(inputs []string) ([]string, error) {
+	seen := make(map[string]bool)
+	var files []string
+	add := func(path string) {
+		if !seen[path] {
+			seen[path] = true
+			files = append(files, path)
+		}
+	}
+
+	for _, input := range inputs {
+		matches := []string{input}
+		if strings.ContainsAny(input, "*?[") {
+			var err error
+			if matches, err = filepath.Glob(input); err != nil {
+				return nil, xerrors.Errorf("invalid glob pattern %q: %w", input, err)
+			}
+			if len(matches) == 0 {
+				return nil, xerrors.Errorf("no files match %q", input)
+			}
+		}
+
+		for _, match := range matches {
+			info, err := os.Stat(match)
+			if err != nil {
+				return nil, xerrors.Errorf("failed to access input: %w", err)
+			}
+			if !info.IsDir() {
+				add(match)
+				continue
+			}
+
+			entries, err := os.ReadDir(match)
+			if err != nil {
+				return nil, xerrors.Errorf("failed to read directory: %w", err)
+			}
+			for _, entry := range entries {
+				if !entry.IsDir() && filepath.Ext(entry.Name()) == ".json" {
+					add(filepath.Join(match, entry.Name()))
+				}
+			}
+		}
+	}
+
+	sort.Strings(files)
+	return files, nil
+}
+
 // Sort transforms a map into a sorted 2D slice based on values (descending)
 func Sort(data map[string]int) [][]string {
 	var items []struct {
@@ -78,8 +249,12 @@ func Sort(data map[string]int) [][]string {
 		}{Key: k, Value: v})
 	}
 
+	// Ties are broken by key so the order does not depend on map iteration
 	sort.Slice(items, func(i, j int) bool {
-		return items[i].Value > items[j].Value
+		if items[i].Value != items[j].Value {
+			return items[i].Value > items[j].Value
+		}
+		return items[i].Key < items[j].Key
 	})
 
 	result := make([][]string, len(items))
@@ -113,4 +288,4 @@ func SetResultClass(rc types.ResultClass) string {
 	default:
 		return string(rc)
 	}
-}
\ No newline at end of file
+}